@@ -0,0 +1,192 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/invenlore/core/pkg/errmodel"
+	"github.com/invenlore/core/pkg/logger"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	logLevelServiceFile = "invenlore/core/admin/v1/loglevel.proto"
+
+	LogLevelServiceName                        = "invenlore.core.admin.v1.LogLevelService"
+	LogLevelService_GetLogLevel_FullMethodName = "/" + LogLevelServiceName + "/GetLogLevel"
+	LogLevelService_SetLogLevel_FullMethodName = "/" + LogLevelServiceName + "/SetLogLevel"
+)
+
+var registerDescriptorOnce sync.Once
+
+// RegisterLogLevelService registers invenlore.core.admin.v1.LogLevelService:
+//
+//	rpc GetLogLevel(google.protobuf.Empty) returns (google.protobuf.Struct);
+//	rpc SetLogLevel(google.protobuf.Struct) returns (google.protobuf.Struct);
+//
+// SetLogLevel takes the same fields as the PUT /loglevel body and fails with
+// PermissionDenied unless WithWrites or WithGRPCAuthorizer is given. There is no
+// generated code for it; the file descriptor is registered at runtime so that
+// server reflection (and grpcurl) can describe the service.
+func RegisterLogLevelService(s grpc.ServiceRegistrar, opts ...Option) {
+	registerDescriptorOnce.Do(registerLogLevelDescriptor)
+
+	s.RegisterService(&logLevelServiceDesc, logLevelServer{options: newOptions(opts)})
+}
+
+type logLevelServer struct {
+	options *options
+}
+
+func (logLevelServer) GetLogLevel(ctx context.Context, _ *emptypb.Empty) (*structpb.Struct, error) {
+	return levelsStruct(ctx, logger.GetLevels())
+}
+
+func (s logLevelServer) SetLogLevel(ctx context.Context, in *structpb.Struct) (*structpb.Struct, error) {
+	if err := s.options.authorizeGRPCWrite(ctx); err != nil {
+		return nil, errmodel.Error(ctx, codes.PermissionDenied, err.Error())
+	}
+
+	data, err := protojson.Marshal(in)
+	if err != nil {
+		return nil, errmodel.Error(ctx, codes.InvalidArgument, "invalid request")
+	}
+
+	var req LogLevelRequest
+
+	if err := json.Unmarshal(data, &req); err != nil {
+		return nil, errmodel.Error(ctx, codes.InvalidArgument, fmt.Sprintf("invalid request: %v", err))
+	}
+
+	levels, err := ApplyLogLevelRequest(&req)
+	if err != nil {
+		return nil, errmodel.Error(ctx, codes.InvalidArgument, err.Error())
+	}
+
+	return levelsStruct(ctx, levels)
+}
+
+func levelsStruct(ctx context.Context, levels logger.Levels) (*structpb.Struct, error) {
+	out := &structpb.Struct{}
+
+	data, err := json.Marshal(levels)
+	if err == nil {
+		err = protojson.Unmarshal(data, out)
+	}
+
+	if err != nil {
+		return nil, errmodel.Error(ctx, codes.Internal, "failed to encode log levels")
+	}
+
+	return out, nil
+}
+
+var logLevelServiceDesc = grpc.ServiceDesc{
+	ServiceName: LogLevelServiceName,
+	HandlerType: (*any)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetLogLevel",
+			Handler:    getLogLevelHandler,
+		},
+		{
+			MethodName: "SetLogLevel",
+			Handler:    setLogLevelHandler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: logLevelServiceFile,
+}
+
+func getLogLevelHandler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+
+	if interceptor == nil {
+		return srv.(logLevelServer).GetLogLevel(ctx, in)
+	}
+
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogLevelService_GetLogLevel_FullMethodName,
+	}
+
+	handler := func(ctx context.Context, req any) (any, error) {
+		return srv.(logLevelServer).GetLogLevel(ctx, req.(*emptypb.Empty))
+	}
+
+	return interceptor(ctx, in, info, handler)
+}
+
+func setLogLevelHandler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := new(structpb.Struct)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+
+	if interceptor == nil {
+		return srv.(logLevelServer).SetLogLevel(ctx, in)
+	}
+
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogLevelService_SetLogLevel_FullMethodName,
+	}
+
+	handler := func(ctx context.Context, req any) (any, error) {
+		return srv.(logLevelServer).SetLogLevel(ctx, req.(*structpb.Struct))
+	}
+
+	return interceptor(ctx, in, info, handler)
+}
+
+func registerLogLevelDescriptor() {
+	fdp := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String(logLevelServiceFile),
+		Package: proto.String("invenlore.core.admin.v1"),
+		Dependency: []string{
+			"google/protobuf/empty.proto",
+			"google/protobuf/struct.proto",
+		},
+		Syntax: proto.String("proto3"),
+		Service: []*descriptorpb.ServiceDescriptorProto{
+			{
+				Name: proto.String("LogLevelService"),
+				Method: []*descriptorpb.MethodDescriptorProto{
+					{
+						Name:       proto.String("GetLogLevel"),
+						InputType:  proto.String(".google.protobuf.Empty"),
+						OutputType: proto.String(".google.protobuf.Struct"),
+					},
+					{
+						Name:       proto.String("SetLogLevel"),
+						InputType:  proto.String(".google.protobuf.Struct"),
+						OutputType: proto.String(".google.protobuf.Struct"),
+					},
+				},
+			},
+		},
+	}
+
+	fd, err := protodesc.NewFile(fdp, protoregistry.GlobalFiles)
+	if err == nil {
+		err = protoregistry.GlobalFiles.RegisterFile(fd)
+	}
+
+	if err != nil {
+		logrus.WithField("scope", "admin").WithError(err).Warn("failed to register log level service descriptor, reflection will not describe it")
+	}
+}
@@ -0,0 +1,176 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/invenlore/core/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+const LogLevelPath = "/loglevel"
+
+// LogLevelRequest changes the log levels at runtime. An empty Level keeps the
+// current base level; ScopeLevels replaces all overrides. RevertAfter is a Go
+// duration such as "15m"; when set, the configured levels are restored after it.
+type LogLevelRequest struct {
+	Level       logger.LogLevel            `json:"level"`
+	ScopeLevels map[string]logger.LogLevel `json:"scope_levels"`
+	RevertAfter string                     `json:"revert_after"`
+	Reset       bool                       `json:"reset"`
+}
+
+type options struct {
+	allowWrites   bool
+	authorize     func(r *http.Request) error
+	authorizeGRPC func(ctx context.Context) error
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+type Option func(*options)
+
+// WithWrites allows changing the levels (PUT, SetLogLevel) without further
+// checks. Only use it when the handler or service is reachable by operators alone.
+func WithWrites() Option {
+	return func(o *options) {
+		o.allowWrites = true
+	}
+}
+
+// WithAuthorizer allows PUT requests for which authorize returns nil;
+// the others get 403 with the returned error.
+func WithAuthorizer(authorize func(r *http.Request) error) Option {
+	return func(o *options) {
+		o.authorize = authorize
+	}
+}
+
+// WithGRPCAuthorizer allows SetLogLevel calls for which authorize returns nil;
+// the others fail with PermissionDenied and the returned error.
+func WithGRPCAuthorizer(authorize func(ctx context.Context) error) Option {
+	return func(o *options) {
+		o.authorizeGRPC = authorize
+	}
+}
+
+// NewHandler serves LogLevelPath and passes everything else to next, e.g.
+// admin.NewHandler(registry.Handler(), admin.WithWrites()) for the metrics server.
+// It must only be mounted on the admin (metrics) port, never on the public HTTP one.
+func NewHandler(next http.Handler, opts ...Option) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.Handle(LogLevelPath, LogLevelHandler(opts...))
+	mux.Handle("/", next)
+
+	return mux
+}
+
+// LogLevelHandler returns the current levels on GET and changes them on PUT.
+// PUT is rejected with 403 unless WithWrites or WithAuthorizer is given.
+func LogLevelHandler(opts ...Option) http.Handler {
+	o := newOptions(opts)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, logger.GetLevels())
+		case http.MethodPut:
+			if err := o.authorizeWrite(r); err != nil {
+				writeError(w, http.StatusForbidden, err)
+				return
+			}
+
+			var req LogLevelRequest
+
+			if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 64<<10)).Decode(&req); err != nil {
+				writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+				return
+			}
+
+			levels, err := ApplyLogLevelRequest(&req)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, err)
+				return
+			}
+
+			writeJSON(w, http.StatusOK, levels)
+		default:
+			w.Header().Set("Allow", "GET, PUT")
+			writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s is not allowed", r.Method))
+		}
+	})
+}
+
+func (o *options) authorizeWrite(r *http.Request) error {
+	if o.authorize != nil {
+		return o.authorize(r)
+	}
+
+	return o.writesAllowed()
+}
+
+func (o *options) authorizeGRPCWrite(ctx context.Context) error {
+	if o.authorizeGRPC != nil {
+		return o.authorizeGRPC(ctx)
+	}
+
+	return o.writesAllowed()
+}
+
+func (o *options) writesAllowed() error {
+	if !o.allowWrites {
+		return errors.New("changing log levels is disabled")
+	}
+
+	return nil
+}
+
+// ApplyLogLevelRequest is shared by the HTTP handler and the gRPC service.
+func ApplyLogLevelRequest(req *LogLevelRequest) (logger.Levels, error) {
+	if req.Reset {
+		return logger.ResetLevels(), nil
+	}
+
+	var revertAfter time.Duration
+
+	if req.RevertAfter != "" {
+		d, err := time.ParseDuration(req.RevertAfter)
+		if err != nil || d < 0 {
+			return logger.Levels{}, fmt.Errorf("invalid revert_after '%s'", req.RevertAfter)
+		}
+
+		revertAfter = d
+	}
+
+	level := req.Level
+	if level == "" {
+		level = logger.GetLevels().Level
+	}
+
+	return logger.SetLevels(level, logger.ScopeLevels(req.ScopeLevels), revertAfter)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logrus.WithField("scope", "admin").WithError(err).Debug("failed to write response")
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
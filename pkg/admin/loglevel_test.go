@@ -0,0 +1,217 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/invenlore/core/pkg/logger"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func resetLevels(t *testing.T) {
+	std := logrus.StandardLogger()
+	prevOut, prevLevel := std.Out, std.GetLevel()
+
+	std.SetOutput(io.Discard)
+
+	t.Cleanup(func() {
+		logger.ResetLevels()
+		std.SetOutput(prevOut)
+		std.SetLevel(prevLevel)
+	})
+}
+
+func TestLogLevelHandler(t *testing.T) {
+	resetLevels(t)
+
+	handler := NewHandler(http.NotFoundHandler(), WithWrites())
+
+	req := httptest.NewRequest(http.MethodPut, LogLevelPath, strings.NewReader(`{"level":"debug","scope_levels":{"gRPC":"trace"},"revert_after":"1h"}`))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	levels := logger.GetLevels()
+	if levels.Level != logger.LogLevelDebug || levels.ScopeLevels["gRPC"] != logger.LogLevelTrace || levels.RevertAt == nil {
+		t.Fatalf("unexpected levels: %+v", levels)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, LogLevelPath, strings.NewReader(`{"level":"loud"}`)))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for unknown level, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, LogLevelPath, nil))
+
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"level":"DEBUG"`) {
+		t.Fatalf("unexpected GET response %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected other paths to reach next handler, got %d", rec.Code)
+	}
+}
+
+func TestLogLevelHandlerGuardsWrites(t *testing.T) {
+	resetLevels(t)
+
+	denied := errors.New("admin token required")
+
+	cases := []struct {
+		name     string
+		opts     []Option
+		token    string
+		expected int
+	}{
+		{name: "no opt-in", expected: http.StatusForbidden},
+		{name: "authorizer rejects", opts: []Option{WithAuthorizer(checkToken(denied))}, expected: http.StatusForbidden},
+		{name: "authorizer accepts", opts: []Option{WithAuthorizer(checkToken(denied))}, token: "secret", expected: http.StatusOK},
+		{name: "writes allowed", opts: []Option{WithWrites()}, expected: http.StatusOK},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPut, LogLevelPath, strings.NewReader(`{"level":"debug"}`))
+			req.Header.Set("X-Admin-Token", tc.token)
+
+			rec := httptest.NewRecorder()
+			LogLevelHandler(tc.opts...).ServeHTTP(rec, req)
+
+			if rec.Code != tc.expected {
+				t.Fatalf("expected %d, got %d: %s", tc.expected, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func checkToken(denied error) func(r *http.Request) error {
+	return func(r *http.Request) error {
+		if r.Header.Get("X-Admin-Token") != "secret" {
+			return denied
+		}
+
+		return nil
+	}
+}
+
+// logLevelServiceConn serves LogLevelService with opts on a loopback port.
+func logLevelServiceConn(t *testing.T, opts ...Option) *grpc.ClientConn {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected listen error: %v", err)
+	}
+
+	srv := grpc.NewServer()
+	RegisterLogLevelService(srv, opts...)
+
+	go func() { _ = srv.Serve(ln) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient(ln.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("unexpected dial error: %v", err)
+	}
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn
+}
+
+func TestLogLevelService(t *testing.T) {
+	resetLevels(t)
+
+	conn := logLevelServiceConn(t, WithWrites())
+	ctx := context.Background()
+
+	in, _ := structpb.NewStruct(map[string]any{"level": "warn"})
+	out := &structpb.Struct{}
+
+	if err := conn.Invoke(ctx, LogLevelService_SetLogLevel_FullMethodName, in, out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := out.GetFields()["level"].GetStringValue(); got != "WARN" {
+		t.Fatalf("expected WARN, got %q", got)
+	}
+
+	if err := conn.Invoke(ctx, LogLevelService_GetLogLevel_FullMethodName, &emptypb.Empty{}, out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := out.GetFields()["level"].GetStringValue(); got != "WARN" {
+		t.Fatalf("expected WARN, got %q", got)
+	}
+
+	in, _ = structpb.NewStruct(map[string]any{"revert_after": "soon"})
+
+	err := conn.Invoke(ctx, LogLevelService_SetLogLevel_FullMethodName, in, out)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestLogLevelServiceGuardsWrites(t *testing.T) {
+	resetLevels(t)
+
+	checkMetadataToken := func(ctx context.Context) error {
+		if vals := metadata.ValueFromIncomingContext(ctx, "x-admin-token"); len(vals) == 0 || vals[0] != "secret" {
+			return errors.New("admin token required")
+		}
+
+		return nil
+	}
+
+	cases := []struct {
+		name     string
+		opts     []Option
+		token    string
+		expected codes.Code
+	}{
+		{name: "no opt-in", expected: codes.PermissionDenied},
+		{name: "authorizer rejects", opts: []Option{WithGRPCAuthorizer(checkMetadataToken)}, expected: codes.PermissionDenied},
+		{name: "authorizer accepts", opts: []Option{WithGRPCAuthorizer(checkMetadataToken)}, token: "secret", expected: codes.OK},
+		{name: "writes allowed", opts: []Option{WithWrites()}, expected: codes.OK},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			conn := logLevelServiceConn(t, tc.opts...)
+			ctx := metadata.AppendToOutgoingContext(context.Background(), "x-admin-token", tc.token)
+
+			in, _ := structpb.NewStruct(map[string]any{"level": "debug"})
+
+			err := conn.Invoke(ctx, LogLevelService_SetLogLevel_FullMethodName, in, &structpb.Struct{})
+			if status.Code(err) != tc.expected {
+				t.Fatalf("expected %s, got %v", tc.expected, err)
+			}
+
+			if err := conn.Invoke(ctx, LogLevelService_GetLogLevel_FullMethodName, &emptypb.Empty{}, &structpb.Struct{}); err != nil {
+				t.Fatalf("expected GetLogLevel to stay open, got %v", err)
+			}
+		})
+	}
+}
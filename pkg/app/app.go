@@ -0,0 +1,202 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/invenlore/core/pkg/config"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultShutdownTimeout = 30 * time.Second
+)
+
+type App struct {
+	shutdownTimeout time.Duration
+	drainDelay      time.Duration
+	drainers        []Drainer
+	signals         []os.Signal
+}
+
+type Option func(*App)
+
+// WithConfig takes the shutdown timeout and drain delay from AppConfig.
+func WithConfig(cfg *config.AppConfig) Option {
+	return func(a *App) {
+		a.shutdownTimeout = cfg.ServiceShutdownTimeout
+		a.drainDelay = cfg.ServiceDrainDelay
+	}
+}
+
+func WithShutdownTimeout(timeout time.Duration) Option {
+	return func(a *App) {
+		a.shutdownTimeout = timeout
+	}
+}
+
+// WithDrainDelay waits after draining and before stopping components,
+// giving load balancers time to observe the not-ready state.
+func WithDrainDelay(delay time.Duration) Option {
+	return func(a *App) {
+		a.drainDelay = delay
+	}
+}
+
+func WithDrainers(drainers ...Drainer) Option {
+	return func(a *App) {
+		a.drainers = append(a.drainers, drainers...)
+	}
+}
+
+// WithSignals replaces the shutdown signals. Without arguments it keeps
+// SIGINT and SIGTERM, as signal.NotifyContext would relay every signal.
+func WithSignals(signals ...os.Signal) Option {
+	return func(a *App) {
+		if len(signals) == 0 {
+			signals = defaultSignals()
+		}
+
+		a.signals = signals
+	}
+}
+
+func New(opts ...Option) *App {
+	a := &App{
+		shutdownTimeout: defaultShutdownTimeout,
+		signals:         defaultSignals(),
+	}
+
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	if a.shutdownTimeout <= 0 {
+		a.shutdownTimeout = defaultShutdownTimeout
+	}
+
+	return a
+}
+
+func defaultSignals() []os.Signal {
+	return []os.Signal{syscall.SIGINT, syscall.SIGTERM}
+}
+
+// Run is New().Run(ctx, components...). Services configured from AppConfig
+// should use RunServers, which also builds the servers and drainers.
+func Run(ctx context.Context, components ...Component) error {
+	return New().Run(ctx, components...)
+}
+
+type componentResult struct {
+	name string
+	err  error
+}
+
+// Run starts all components and blocks until ctx is done, a signal is received
+// or a component fails. Then it drains, stops components in reverse order within
+// the shutdown timeout and returns the first fatal error (or the stop errors).
+func (a *App) Run(ctx context.Context, components ...Component) error {
+	loggerEntry := logrus.WithField("scope", "app")
+
+	signalCtx, stopSignals := signal.NotifyContext(ctx, a.signals...)
+	defer stopSignals()
+
+	cancels := make([]context.CancelFunc, len(components))
+	results := make(chan componentResult, len(components))
+
+	for i, c := range components {
+		componentCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		cancels[i] = cancel
+
+		loggerEntry.Infof("starting component '%s'...", c.Name())
+
+		go func(c Component) {
+			results <- componentResult{name: c.Name(), err: c.Start(componentCtx)}
+		}(c)
+	}
+
+	var (
+		fatalErr error
+		running  = len(components)
+	)
+
+wait:
+	for running > 0 {
+		select {
+		case <-signalCtx.Done():
+			loggerEntry.Info("shutdown requested")
+			break wait
+		case res := <-results:
+			running--
+
+			if res.err != nil {
+				fatalErr = fmt.Errorf("component '%s' failed: %w", res.name, res.err)
+				loggerEntry.WithError(res.err).Errorf("component '%s' failed", res.name)
+
+				break wait
+			}
+
+			loggerEntry.Warnf("component '%s' stopped unexpectedly", res.name)
+		}
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), a.shutdownTimeout)
+	defer cancel()
+
+	for _, d := range a.drainers {
+		d.Drain(shutdownCtx)
+	}
+
+	if a.drainDelay > 0 && len(a.drainers) > 0 {
+		loggerEntry.Debugf("waiting %s before stopping components", a.drainDelay)
+
+		select {
+		case <-time.After(a.drainDelay):
+		case <-shutdownCtx.Done():
+		}
+	}
+
+	var stopErrs []error
+
+	for i := len(components) - 1; i >= 0; i-- {
+		c := components[i]
+
+		loggerEntry.Infof("stopping component '%s'...", c.Name())
+
+		if err := c.Stop(shutdownCtx); err != nil {
+			stopErrs = append(stopErrs, fmt.Errorf("failed to stop component '%s': %w", c.Name(), err))
+			loggerEntry.WithError(err).Errorf("failed to stop component '%s'", c.Name())
+		}
+
+		cancels[i]()
+	}
+
+	for running > 0 {
+		select {
+		case res := <-results:
+			running--
+
+			if res.err != nil && fatalErr == nil {
+				fatalErr = fmt.Errorf("component '%s' failed: %w", res.name, res.err)
+			}
+		case <-shutdownCtx.Done():
+			loggerEntry.Errorf("shutdown deadline exceeded, %d component(s) still running", running)
+
+			return errors.Join(fatalErr, shutdownCtx.Err())
+		}
+	}
+
+	loggerEntry.Info("all components stopped")
+
+	if fatalErr != nil {
+		return fatalErr
+	}
+
+	return errors.Join(stopErrs...)
+}
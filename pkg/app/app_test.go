@@ -0,0 +1,66 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+type recorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *recorder) add(event string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events = append(r.events, event)
+}
+
+func (r *recorder) Drain(_ context.Context) {
+	r.add("drain")
+}
+
+func recordingComponent(r *recorder, name string) Component {
+	return Func(name, nil, func(_ context.Context) error {
+		r.add("stop " + name)
+		return nil
+	})
+}
+
+func TestRunDrainsAndStopsInReverseOrder(t *testing.T) {
+	rec := &recorder{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	err := New(WithDrainers(rec)).Run(ctx,
+		recordingComponent(rec, "first"),
+		recordingComponent(rec, "second"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"drain", "stop second", "stop first"}
+	if !reflect.DeepEqual(rec.events, expected) {
+		t.Fatalf("expected %v, got %v", expected, rec.events)
+	}
+}
+
+func TestRunReturnsFirstFatalError(t *testing.T) {
+	boom := errors.New("boom")
+
+	err := Run(context.Background(),
+		Func("healthy", nil, nil),
+		Func("broken", func(_ context.Context) error { return boom }, nil),
+	)
+
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected boom, got %v", err)
+	}
+}
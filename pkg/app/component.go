@@ -0,0 +1,140 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+
+	"google.golang.org/grpc"
+)
+
+// Component is a long-running part of a service.
+// Start blocks until the component stops or fails; Stop makes Start return.
+type Component interface {
+	Name() string
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
+}
+
+// Drainer is flipped to not-ready before components are stopped,
+// so that load balancers stop sending new traffic.
+type Drainer interface {
+	Drain(ctx context.Context)
+}
+
+type httpComponent struct {
+	name   string
+	server *http.Server
+	ln     net.Listener
+}
+
+// HTTPServer wraps a server and listener such as those returned by metrics.StartMetricsServer.
+func HTTPServer(name string, server *http.Server, ln net.Listener) Component {
+	return &httpComponent{
+		name:   name,
+		server: server,
+		ln:     ln,
+	}
+}
+
+func (c *httpComponent) Name() string {
+	return c.name
+}
+
+func (c *httpComponent) Start(_ context.Context) error {
+	err := c.server.Serve(c.ln)
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+
+	return err
+}
+
+func (c *httpComponent) Stop(ctx context.Context) error {
+	return c.server.Shutdown(ctx)
+}
+
+type grpcComponent struct {
+	name   string
+	server *grpc.Server
+	ln     net.Listener
+}
+
+func GRPCServer(name string, server *grpc.Server, ln net.Listener) Component {
+	return &grpcComponent{
+		name:   name,
+		server: server,
+		ln:     ln,
+	}
+}
+
+func (c *grpcComponent) Name() string {
+	return c.name
+}
+
+func (c *grpcComponent) Start(_ context.Context) error {
+	err := c.server.Serve(c.ln)
+	if errors.Is(err, grpc.ErrServerStopped) {
+		return nil
+	}
+
+	return err
+}
+
+// Stop waits for in-flight RPCs and falls back to a hard stop when ctx expires.
+func (c *grpcComponent) Stop(ctx context.Context) error {
+	done := make(chan struct{})
+
+	go func() {
+		c.server.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		c.server.Stop()
+		<-done
+
+		return ctx.Err()
+	}
+}
+
+type funcComponent struct {
+	name  string
+	start func(ctx context.Context) error
+	stop  func(ctx context.Context) error
+}
+
+// Func builds a Component from plain functions, e.g. for background loops
+// like MongoReadiness.Run or closing a grpcclient.Pool. Either function may be nil.
+func Func(name string, start, stop func(ctx context.Context) error) Component {
+	return &funcComponent{
+		name:  name,
+		start: start,
+		stop:  stop,
+	}
+}
+
+func (c *funcComponent) Name() string {
+	return c.name
+}
+
+func (c *funcComponent) Start(ctx context.Context) error {
+	if c.start == nil {
+		<-ctx.Done()
+		return nil
+	}
+
+	return c.start(ctx)
+}
+
+func (c *funcComponent) Stop(ctx context.Context) error {
+	if c.stop == nil {
+		return nil
+	}
+
+	return c.stop(ctx)
+}
@@ -0,0 +1,45 @@
+package app
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/invenlore/core/pkg/metrics"
+	"github.com/sirupsen/logrus"
+)
+
+const readinessComponent = "app"
+
+// Readiness reports ready until Run starts shutting down.
+type Readiness struct {
+	draining atomic.Bool
+	gauge    *metrics.ReadinessGauge
+}
+
+func NewReadiness(gauge *metrics.ReadinessGauge) *Readiness {
+	r := &Readiness{gauge: gauge}
+	r.gauge.Set(readinessComponent, true)
+
+	return r
+}
+
+func (r *Readiness) Ready() bool {
+	return !r.draining.Load()
+}
+
+func (r *Readiness) LastError() string {
+	if r.draining.Load() {
+		return "service is shutting down"
+	}
+
+	return ""
+}
+
+func (r *Readiness) Drain(_ context.Context) {
+	if r.draining.Swap(true) {
+		return
+	}
+
+	r.gauge.Set(readinessComponent, false)
+	logrus.WithField("scope", "app").Info("readiness set to not ready, draining")
+}
@@ -0,0 +1,215 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/invenlore/core/pkg/admin"
+	"github.com/invenlore/core/pkg/config"
+	"github.com/invenlore/core/pkg/gateway"
+	"github.com/invenlore/core/pkg/grpcclient"
+	"github.com/invenlore/core/pkg/grpcserver"
+	"github.com/invenlore/core/pkg/health"
+	"github.com/invenlore/core/pkg/metrics"
+	"google.golang.org/grpc"
+)
+
+const (
+	defaultGRPCHealthInterval = 5 * time.Second
+)
+
+// Servers describes what RunServers serves. The health and metrics servers are
+// always started; gRPC and HTTP only when configured here.
+type Servers struct {
+	// RegisterGRPC registers the service implementations and their health
+	// sources. The gRPC server on cfg.GRPC is only started when it is set.
+	RegisterGRPC func(s *grpc.Server, h *health.GRPCHealthServer)
+	GRPCOptions  []grpcserver.Option
+
+	// Gateway serves cfg.GRPCServices through grpc-gateway on cfg.HTTP,
+	// using a grpcclient.Pool that is closed on shutdown.
+	Gateway bool
+	// HTTPHandler is served on cfg.HTTP instead of the gateway.
+	HTTPHandler http.Handler
+
+	// Probes are served on cfg.Health. The app readiness is added to Readiness,
+	// so draining fails /readyz.
+	Probes        health.Probes
+	HealthOptions []health.Option
+
+	// Metrics is served on cfg.Metrics together with admin.LogLevelPath. A
+	// registry is built from cfg.Service when nil. Log level writes are allowed
+	// by cfg.Metrics.LogLevelWrites or an admin.WithAuthorizer in AdminOptions.
+	Metrics      *metrics.Registry
+	AdminOptions []admin.Option
+
+	// Components run alongside the servers (App.Run starts everything at once)
+	// and are stopped after them, e.g. MongoReadiness.Run.
+	Components []Component
+}
+
+// Stack is the result of BuildServers.
+type Stack struct {
+	Components []Component
+	Drainers   []Drainer
+	Readiness  *Readiness
+	Registry   *metrics.Registry
+	GRPCHealth *health.GRPCHealthServer
+	Health     *health.Handler
+	Metrics    http.Handler
+}
+
+// RunServers builds the servers from cfg (see BuildServers) and runs them with
+// the shutdown timeout and drain delay of cfg. opts are applied after WithConfig.
+func RunServers(ctx context.Context, cfg *config.AppConfig, servers Servers, opts ...Option) error {
+	stack, err := BuildServers(ctx, cfg, servers)
+	if err != nil {
+		return err
+	}
+
+	opts = append([]Option{WithConfig(cfg), WithDrainers(stack.Drainers...)}, opts...)
+
+	return New(opts...).Run(ctx, stack.Components...)
+}
+
+// BuildServers opens the listeners of the gRPC, HTTP, health and metrics
+// servers, registers the gRPC and slow-call metrics and wires the drainers:
+// the app Readiness (failing /readyz) and the gRPC health server (reporting
+// NOT_SERVING). Listeners opened before an error are closed.
+func BuildServers(ctx context.Context, cfg *config.AppConfig, servers Servers) (_ *Stack, err error) {
+	registry := servers.Metrics
+	if registry == nil {
+		registry = metrics.NewRegistryFromInfo(*cfg.GetServiceInfo())
+	}
+
+	readiness := NewReadiness(metrics.NewReadinessGauge(registry))
+	slowCalls := metrics.NewSlowCallMetrics(registry)
+
+	stack := &Stack{
+		Drainers:  []Drainer{readiness},
+		Readiness: readiness,
+		Registry:  registry,
+	}
+
+	closers := []func() error{func() error {
+		slowCalls.Close()
+		return nil
+	}}
+
+	defer func() {
+		if err != nil {
+			for _, closeFn := range closers {
+				_ = closeFn()
+			}
+		}
+	}()
+
+	if servers.RegisterGRPC != nil {
+		grpcHealth := health.NewGRPCHealthServer()
+		grpcHealth.AddService("", readiness)
+
+		grpcOpts := append([]grpcserver.Option{
+			grpcserver.WithMetrics(metrics.NewGRPCServerMetrics(registry)),
+			grpcserver.WithHealthServer(grpcHealth),
+		}, servers.GRPCOptions...)
+
+		srv, err := grpcserver.New(&cfg.GRPC, grpcOpts...)
+		if err != nil {
+			return nil, err
+		}
+
+		closers = append(closers, srv.Listener.Close)
+
+		servers.RegisterGRPC(srv.Server, grpcHealth)
+
+		interval := cfg.Health.CheckInterval
+		if interval <= 0 {
+			interval = defaultGRPCHealthInterval
+		}
+
+		stack.GRPCHealth = grpcHealth
+		stack.Drainers = append(stack.Drainers, grpcHealth)
+		stack.Components = append(stack.Components,
+			Func("gRPC health", func(ctx context.Context) error {
+				grpcHealth.Run(ctx, interval)
+				return nil
+			}, nil),
+			GRPCServer("gRPC", srv.Server, srv.Listener),
+		)
+	}
+
+	handler := servers.HTTPHandler
+
+	if handler == nil && servers.Gateway {
+		pool := grpcclient.NewPool(grpcclient.WithMetrics(metrics.NewGRPCClientMetrics(registry)))
+		closers = append(closers, pool.Close)
+
+		if handler, err = gateway.NewHandler(ctx, pool, cfg.GRPCServices); err != nil {
+			return nil, err
+		}
+
+		// Registered before the HTTP server, so the pool is closed after it.
+		stack.Components = append(stack.Components, Func("gRPC client pool", nil, func(_ context.Context) error {
+			return pool.Close()
+		}))
+	}
+
+	if handler != nil {
+		server, ln, err := gateway.StartGatewayServer(&cfg.HTTP, handler)
+		if err != nil {
+			return nil, err
+		}
+
+		closers = append(closers, ln.Close)
+		stack.Components = append(stack.Components, HTTPServer("HTTP", server, ln))
+	}
+
+	probes := servers.Probes
+	probes.Readiness = append(append([]health.Check{}, probes.Readiness...), health.ReadinessCheck("app", readiness))
+
+	healthOpts := append([]health.Option{health.WithMetrics(metrics.NewHealthMetrics(registry))}, servers.HealthOptions...)
+	stack.Health = health.NewProbesHandler(cfg, probes, healthOpts...)
+
+	closers = append(closers, func() error {
+		stack.Health.Stop()
+		return nil
+	})
+
+	healthServer, healthLn, err := health.StartHealthServer(&cfg.Health, stack.Health)
+	if err != nil {
+		return nil, err
+	}
+
+	closers = append(closers, healthLn.Close)
+
+	var adminOpts []admin.Option
+	if cfg.Metrics.LogLevelWrites {
+		adminOpts = append(adminOpts, admin.WithWrites())
+	}
+
+	stack.Metrics = admin.NewHandler(registry.Handler(), append(adminOpts, servers.AdminOptions...)...)
+
+	metricsServer, metricsLn, err := metrics.StartMetricsServer(&cfg.Metrics, stack.Metrics)
+	if err != nil {
+		return nil, err
+	}
+
+	// Probes and metrics keep answering until the other servers have stopped.
+	components := append([]Component{}, servers.Components...)
+	components = append(components,
+		Func("slow call metrics", nil, func(_ context.Context) error {
+			slowCalls.Close()
+			return nil
+		}),
+		Func("health checks", nil, func(_ context.Context) error {
+			stack.Health.Stop()
+			return nil
+		}),
+		HTTPServer("health", healthServer, healthLn),
+		HTTPServer("metrics", metricsServer, metricsLn),
+	)
+	stack.Components = append(components, stack.Components...)
+
+	return stack, nil
+}
@@ -0,0 +1,178 @@
+package app
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/invenlore/core/pkg/admin"
+	"github.com/invenlore/core/pkg/config"
+	"github.com/invenlore/core/pkg/health"
+	"github.com/invenlore/core/pkg/logger"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type drainFunc func(ctx context.Context)
+
+func (f drainFunc) Drain(ctx context.Context) {
+	f(ctx)
+}
+
+func testServersConfig() *config.AppConfig {
+	return &config.AppConfig{
+		ServiceName:          "test",
+		ServiceHealthTimeout: time.Second,
+		GRPC:                 config.GRPCServerConfig{Host: "127.0.0.1", Port: "0"},
+		Health: config.HealthServerConfig{
+			Host:          "127.0.0.1",
+			Port:          "0",
+			CacheDuration: time.Nanosecond,
+			CheckTimeout:  time.Second,
+		},
+		Metrics: config.MetricsServerConfig{Host: "127.0.0.1", Port: "0"},
+	}
+}
+
+func readyzStatus(h http.Handler) int {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, health.ReadinessPath, nil))
+
+	return rec.Code
+}
+
+func grpcHealthStatus(t *testing.T, h *health.GRPCHealthServer) healthpb.HealthCheckResponse_ServingStatus {
+	t.Helper()
+
+	resp, err := h.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("unexpected health check error: %v", err)
+	}
+
+	return resp.GetStatus()
+}
+
+func TestRunServersDrainFlipsProbesAndGRPCHealth(t *testing.T) {
+	var registered bool
+
+	stack, err := BuildServers(context.Background(), testServersConfig(), Servers{
+		RegisterGRPC: func(_ *grpc.Server, _ *health.GRPCHealthServer) {
+			registered = true
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected build error: %v", err)
+	}
+
+	if !registered {
+		t.Fatalf("expected RegisterGRPC to be called")
+	}
+
+	stack.GRPCHealth.Update()
+
+	if code := readyzStatus(stack.Health); code != http.StatusOK {
+		t.Fatalf("expected /readyz 200 before drain, got %d", code)
+	}
+
+	if status := grpcHealthStatus(t, stack.GRPCHealth); status != healthpb.HealthCheckResponse_SERVING {
+		t.Fatalf("expected SERVING before drain, got %s", status)
+	}
+
+	var (
+		drainedCode   int
+		drainedStatus healthpb.HealthCheckResponse_ServingStatus
+	)
+
+	// Runs after the stack drainers and before any component is stopped.
+	inspect := drainFunc(func(_ context.Context) {
+		drainedCode = readyzStatus(stack.Health)
+		drainedStatus = grpcHealthStatus(t, stack.GRPCHealth)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	err = New(WithDrainers(stack.Drainers...), WithDrainers(inspect)).Run(ctx, stack.Components...)
+	if err != nil {
+		t.Fatalf("unexpected run error: %v", err)
+	}
+
+	if drainedCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected /readyz 503 while draining, got %d", drainedCode)
+	}
+
+	if drainedStatus != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Fatalf("expected NOT_SERVING while draining, got %s", drainedStatus)
+	}
+}
+
+func TestBuildServersGuardsLogLevelWrites(t *testing.T) {
+	std := logrus.StandardLogger()
+	prevOut, prevLevel := std.Out, std.GetLevel()
+
+	std.SetOutput(io.Discard)
+
+	t.Cleanup(func() {
+		logger.ResetLevels()
+		std.SetOutput(prevOut)
+		std.SetLevel(prevLevel)
+	})
+
+	cases := []struct {
+		name     string
+		writes   bool
+		expected int
+	}{
+		{name: "writes disabled by default", expected: http.StatusForbidden},
+		{name: "writes enabled by config", writes: true, expected: http.StatusOK},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := testServersConfig()
+			cfg.Metrics.LogLevelWrites = tc.writes
+
+			stack, err := BuildServers(context.Background(), cfg, Servers{})
+			if err != nil {
+				t.Fatalf("unexpected build error: %v", err)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			t.Cleanup(func() { _ = New().Run(ctx, stack.Components...) })
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPut, admin.LogLevelPath, strings.NewReader(`{"level":"debug"}`))
+			stack.Metrics.ServeHTTP(rec, req)
+
+			if rec.Code != tc.expected {
+				t.Fatalf("expected %d, got %d (%s)", tc.expected, rec.Code, rec.Body.String())
+			}
+
+			rec = httptest.NewRecorder()
+			stack.Metrics.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("expected /metrics 200, got %d", rec.Code)
+			}
+		})
+	}
+}
+
+func TestWithSignalsFallsBackToDefaults(t *testing.T) {
+	a := New(WithSignals())
+
+	expected := []os.Signal{syscall.SIGINT, syscall.SIGTERM}
+	if !reflect.DeepEqual(a.signals, expected) {
+		t.Fatalf("expected %v, got %v", expected, a.signals)
+	}
+}
@@ -0,0 +1,105 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/invenlore/core/pkg/errmodel"
+	"github.com/invenlore/core/pkg/logger"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type Outcome string
+
+const (
+	OutcomeSuccess Outcome = "success"
+	OutcomeFailure Outcome = "failure"
+	OutcomeDenied  Outcome = "denied"
+)
+
+// Event is a single audit record, e.g. Action "auth.login" by Actor "user:42"
+// or Action "keys.rotate" by Actor "system".
+type Event struct {
+	Time      time.Time         `json:"time" bson:"time"`
+	Service   string            `json:"service,omitempty" bson:"service,omitempty"`
+	Actor     string            `json:"actor" bson:"actor"`
+	Action    string            `json:"action" bson:"action"`
+	Target    string            `json:"target,omitempty" bson:"target,omitempty"`
+	Outcome   Outcome           `json:"outcome" bson:"outcome"`
+	Reason    string            `json:"reason,omitempty" bson:"reason,omitempty"`
+	RequestID string            `json:"request_id,omitempty" bson:"requestId,omitempty"`
+	TraceID   string            `json:"trace_id,omitempty" bson:"traceId,omitempty"`
+	Metadata  map[string]string `json:"metadata,omitempty" bson:"metadata,omitempty"`
+}
+
+// Sink persists audit events. Implementations must be safe for concurrent use.
+type Sink interface {
+	Write(ctx context.Context, event Event) error
+}
+
+// Auditor writes every event to all of its sinks. Audit events never go
+// through the application logger, so log levels and sampling do not apply.
+type Auditor struct {
+	service string
+	sinks   []Sink
+}
+
+func New(service string, sinks ...Sink) *Auditor {
+	return &Auditor{
+		service: service,
+		sinks:   sinks,
+	}
+}
+
+// Record fills Time, Service, RequestID, TraceID and, from the user_id set by
+// logger.WithUserID, Actor when they are empty, then writes the event to every
+// sink. A failing sink does not stop the others; all errors are returned joined.
+func (a *Auditor) Record(ctx context.Context, event Event) error {
+	if a == nil {
+		return nil
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+
+	if event.Service == "" {
+		event.Service = a.service
+	}
+
+	if event.RequestID == "" {
+		event.RequestID = errmodel.RequestIDFromContext(ctx)
+	}
+
+	if event.TraceID == "" {
+		if spanCtx := trace.SpanContextFromContext(ctx); spanCtx.IsValid() {
+			event.TraceID = spanCtx.TraceID().String()
+		}
+	}
+
+	if event.Actor == "" {
+		event.Actor, _ = logger.FromContext(ctx).Data["user_id"].(string)
+	}
+
+	var errs []error
+
+	for _, sink := range a.sinks {
+		if err := sink.Write(ctx, event); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"scope":      "audit",
+				"request_id": event.RequestID,
+				"action":     event.Action,
+			}).WithError(err).Error("failed to write audit event")
+
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
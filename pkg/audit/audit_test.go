@@ -0,0 +1,59 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/invenlore/core/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+type failingSink struct{}
+
+func (failingSink) Write(context.Context, Event) error {
+	return errors.New("unavailable")
+}
+
+func TestRecordFillsContextAndWritesAllSinks(t *testing.T) {
+	prevLevel := logrus.GetLevel()
+	t.Cleanup(func() { logrus.SetLevel(prevLevel) })
+
+	// The application log level must not affect audit events.
+	logrus.SetLevel(logrus.PanicLevel)
+
+	var out bytes.Buffer
+
+	auditor := New("identity", failingSink{}, NewLogSink(&out))
+
+	ctx := logger.WithUserID(logger.WithRequestID(context.Background(), "req-1"), "user-42")
+
+	err := auditor.Record(ctx, Event{
+		Action:  "auth.login",
+		Outcome: OutcomeSuccess,
+	})
+	if err == nil {
+		t.Fatalf("expected the failing sink error to be returned")
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("expected a json line, got %q: %v", out.String(), err)
+	}
+
+	want := map[string]any{
+		"action":     "auth.login",
+		"actor":      "user-42",
+		"outcome":    "success",
+		"service":    "identity",
+		"request_id": "req-1",
+	}
+
+	for key, value := range want {
+		if got[key] != value {
+			t.Fatalf("expected %s=%v, got %v (line %s)", key, value, got[key], out.String())
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/invenlore/core/pkg/config"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	OutputNone   = "none"
+	OutputStdout = "stdout"
+	OutputStderr = "stderr"
+	OutputFile   = "file"
+)
+
+// NewFromConfig builds an Auditor with the sinks selected by cfg.Audit. db is
+// only used when MONGO_ENABLED is set. The returned function closes the audit
+// log file, if any, and must be called on shutdown.
+func NewFromConfig(ctx context.Context, cfg *config.AppConfig, db *mongo.Database) (*Auditor, func() error, error) {
+	var (
+		auditCfg = cfg.GetAuditConfig()
+		sinks    []Sink
+		closer   = func() error { return nil }
+	)
+
+	switch auditCfg.Output {
+	case OutputNone:
+	case OutputStdout, "":
+		sinks = append(sinks, NewLogSink(os.Stdout))
+	case OutputStderr:
+		sinks = append(sinks, NewLogSink(os.Stderr))
+	case OutputFile:
+		sink, err := NewFileLogSink(auditCfg.FilePath)
+		if err != nil {
+			return nil, closer, err
+		}
+
+		sinks = append(sinks, sink)
+		closer = sink.Close
+	default:
+		return nil, closer, fmt.Errorf("unknown audit output '%s'", auditCfg.Output)
+	}
+
+	if auditCfg.MongoEnabled {
+		if db == nil {
+			_ = closer()
+
+			return nil, func() error { return nil }, errors.New("audit MongoDB sink is enabled but no database was given")
+		}
+
+		sink, err := NewMongoSink(ctx, db, MongoSinkConfig{
+			Collection: auditCfg.MongoCollection,
+			Retention:  auditCfg.Retention,
+			OpTimeout:  cfg.GetMongoConfig().OperationTimeout,
+		})
+		if err != nil {
+			_ = closer()
+
+			return nil, func() error { return nil }, err
+		}
+
+		sinks = append(sinks, sink)
+	}
+
+	return New(cfg.GetServiceInfo().Name, sinks...), closer, nil
+}
@@ -0,0 +1,93 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// LogSink writes events as JSON lines through its own logrus logger, separate
+// from the standard one, so it has no level filter, sampling or hooks.
+type LogSink struct {
+	logger *logrus.Logger
+	closer io.Closer
+	mu     sync.Mutex
+}
+
+func NewLogSink(w io.Writer) *LogSink {
+	l := logrus.New()
+
+	l.SetOutput(w)
+	l.SetLevel(logrus.InfoLevel)
+	l.SetFormatter(&logrus.JSONFormatter{
+		TimestampFormat: time.RFC3339Nano,
+		FieldMap: logrus.FieldMap{
+			logrus.FieldKeyTime:  "timestamp",
+			logrus.FieldKeyLevel: "level",
+			logrus.FieldKeyMsg:   "message",
+		},
+	})
+
+	return &LogSink{logger: l}
+}
+
+// NewFileLogSink appends events to path. The file is never rotated or truncated
+// by the sink.
+func NewFileLogSink(path string) (*LogSink, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o640)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log file: %w", err)
+	}
+
+	sink := NewLogSink(f)
+	sink.closer = f
+
+	return sink, nil
+}
+
+func (s *LogSink) Write(_ context.Context, event Event) error {
+	fields := logrus.Fields{
+		"audit":   true,
+		"actor":   event.Actor,
+		"action":  event.Action,
+		"outcome": string(event.Outcome),
+	}
+
+	optional := map[string]string{
+		"service":    event.Service,
+		"target":     event.Target,
+		"reason":     event.Reason,
+		"request_id": event.RequestID,
+		"trace_id":   event.TraceID,
+	}
+
+	for key, value := range optional {
+		if value != "" {
+			fields[key] = value
+		}
+	}
+
+	if len(event.Metadata) > 0 {
+		fields["metadata"] = event.Metadata
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.logger.WithFields(fields).WithTime(event.Time).Info("audit event")
+
+	return nil
+}
+
+func (s *LogSink) Close() error {
+	if s.closer == nil {
+		return nil
+	}
+
+	return s.closer.Close()
+}
@@ -0,0 +1,94 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	DefaultMongoCollection = "audit_events"
+
+	ttlIndexName = "ttl_time"
+)
+
+// MongoSinkConfig: Retention 0 keeps events forever; otherwise a TTL index on
+// "time" removes them after Retention.
+type MongoSinkConfig struct {
+	Collection string
+	Retention  time.Duration
+	OpTimeout  time.Duration
+}
+
+type MongoSink struct {
+	col *mongo.Collection
+	cfg MongoSinkConfig
+}
+
+// NewMongoSink creates the TTL index when Retention is set. An existing
+// ttl_time index with a different expiry is updated with collMod.
+func NewMongoSink(ctx context.Context, db *mongo.Database, cfg MongoSinkConfig) (*MongoSink, error) {
+	if cfg.Collection == "" {
+		cfg.Collection = DefaultMongoCollection
+	}
+
+	if cfg.OpTimeout <= 0 {
+		cfg.OpTimeout = 5 * time.Second
+	}
+
+	s := &MongoSink{
+		col: db.Collection(cfg.Collection),
+		cfg: cfg,
+	}
+
+	if cfg.Retention > 0 {
+		if err := s.ensureTTLIndex(ctx); err != nil {
+			return nil, fmt.Errorf("failed to create audit TTL index: %w", err)
+		}
+	}
+
+	return s, nil
+}
+
+func (s *MongoSink) Write(ctx context.Context, event Event) error {
+	// The event is written even if the request that triggered it was cancelled.
+	opCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.cfg.OpTimeout)
+	defer cancel()
+
+	_, err := s.col.InsertOne(opCtx, event)
+
+	return err
+}
+
+func (s *MongoSink) ensureTTLIndex(ctx context.Context) error {
+	opCtx, cancel := context.WithTimeout(ctx, s.cfg.OpTimeout)
+	defer cancel()
+
+	expireAfter := int32(s.cfg.Retention / time.Second)
+
+	_, err := s.col.Indexes().CreateOne(opCtx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "time", Value: 1}},
+		Options: options.Index().SetName(ttlIndexName).SetExpireAfterSeconds(expireAfter),
+	})
+	if err == nil {
+		return nil
+	}
+
+	var cmdErr mongo.CommandError
+	if !errors.As(err, &cmdErr) || cmdErr.Name != "IndexOptionsConflict" {
+		return err
+	}
+
+	return s.col.Database().RunCommand(opCtx, bson.D{
+		{Key: "collMod", Value: s.cfg.Collection},
+		{Key: "index", Value: bson.D{
+			{Key: "name", Value: ttlIndexName},
+			{Key: "expireAfterSeconds", Value: expireAfter},
+		}},
+	}).Err()
+}
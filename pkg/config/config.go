@@ -2,16 +2,16 @@ package config
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
-	"path/filepath"
-	"strings"
 	"sync"
 	"time"
 
 	"github.com/caarlos0/env/v11"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/invenlore/core/pkg/logger"
+	"github.com/invenlore/core/pkg/serviceinfo"
 	identity_v1 "github.com/invenlore/proto/pkg/identity/v1"
 	media_v1 "github.com/invenlore/proto/pkg/media/v1"
 	search_v1 "github.com/invenlore/proto/pkg/search/v1"
@@ -31,6 +31,54 @@ type GRPCService struct {
 	Name            string
 	Address         string
 	RegisterEntries []RegisterEntry
+	Client          GRPCClientConfig
+}
+
+type GRPCClientTLSConfig struct {
+	Enabled            bool   `env:"ENABLED" envDefault:"false"`
+	CAFile             string `env:"CA_FILE"`
+	CertFile           string `env:"CERT_FILE"`
+	KeyFile            string `env:"KEY_FILE"`
+	ServerName         string `env:"SERVER_NAME"`
+	InsecureSkipVerify bool   `env:"INSECURE_SKIP_VERIFY" envDefault:"false"`
+}
+
+type GRPCClientKeepaliveConfig struct {
+	Time                time.Duration `env:"TIME" envDefault:"0s"`
+	Timeout             time.Duration `env:"TIMEOUT" envDefault:"20s"`
+	PermitWithoutStream bool          `env:"PERMIT_WITHOUT_STREAM" envDefault:"false"`
+}
+
+// GRPCClientConfig is parsed per service with the <NAME>_SERVICE_ prefix,
+// e.g. IDENTITY_SERVICE_TIMEOUT or WIKI_READ_SERVICE_TLS_CA_FILE.
+type GRPCClientConfig struct {
+	Timeout             time.Duration             `env:"TIMEOUT" envDefault:"10s"`
+	MaxRecvMsgSize      int                       `env:"MAX_RECV_MSG_SIZE" envDefault:"0"`
+	MaxSendMsgSize      int                       `env:"MAX_SEND_MSG_SIZE" envDefault:"0"`
+	ServiceConfig       string                    `env:"SERVICE_CONFIG"`
+	LoadBalancingPolicy string                    `env:"LB_POLICY"`
+	TLS                 GRPCClientTLSConfig       `envPrefix:"TLS_"`
+	Keepalive           GRPCClientKeepaliveConfig `envPrefix:"KEEPALIVE_"`
+}
+
+func (c *GRPCClientConfig) Validate() error {
+	if c.ServiceConfig != "" && !json.Valid([]byte(c.ServiceConfig)) {
+		return fmt.Errorf("service config is not valid JSON")
+	}
+
+	if c.ServiceConfig != "" && c.LoadBalancingPolicy != "" {
+		return fmt.Errorf("service config and load balancing policy are mutually exclusive")
+	}
+
+	if (c.TLS.CertFile == "") != (c.TLS.KeyFile == "") {
+		return fmt.Errorf("TLS client certificate and key must be set together")
+	}
+
+	if c.MaxRecvMsgSize < 0 || c.MaxSendMsgSize < 0 {
+		return fmt.Errorf("max message sizes must not be negative")
+	}
+
+	return nil
 }
 
 type GRPCServerConfig struct {
@@ -54,8 +102,15 @@ type HealthServerConfig struct {
 	WriteTimeout      time.Duration `env:"WRITE_TIMEOUT" envDefault:"10s"`
 	IdleTimeout       time.Duration `env:"IDLE_TIMEOUT" envDefault:"60s"`
 	ReadHeaderTimeout time.Duration `env:"READ_HEADER_TIMEOUT" envDefault:"5s"`
+	Verbose           bool          `env:"VERBOSE" envDefault:"false"`
+	CacheDuration     time.Duration `env:"CACHE_DURATION" envDefault:"1s"`
+	CheckTimeout      time.Duration `env:"CHECK_TIMEOUT" envDefault:"10s"`
+	CheckInterval     time.Duration `env:"CHECK_INTERVAL" envDefault:"0s"`
+	CheckInitialDelay time.Duration `env:"CHECK_INITIAL_DELAY" envDefault:"0s"`
 }
 
+// MetricsServerConfig also serves /loglevel; LOG_LEVEL_WRITES allows changing
+// the levels with PUT there.
 type MetricsServerConfig struct {
 	Host              string        `env:"HOST" envDefault:"0.0.0.0"`
 	Port              string        `env:"PORT" envDefault:"9090"`
@@ -63,6 +118,7 @@ type MetricsServerConfig struct {
 	WriteTimeout      time.Duration `env:"WRITE_TIMEOUT" envDefault:"10s"`
 	IdleTimeout       time.Duration `env:"IDLE_TIMEOUT" envDefault:"60s"`
 	ReadHeaderTimeout time.Duration `env:"READ_HEADER_TIMEOUT" envDefault:"5s"`
+	LogLevelWrites    bool          `env:"LOG_LEVEL_WRITES" envDefault:"false"`
 }
 
 type MongoConfig struct {
@@ -121,12 +177,97 @@ type RateLimitConfig struct {
 	Other             RateLimitGroupConfig `envPrefix:"OTHER_"`
 }
 
+// TracingConfig selects the span exporter: "none" keeps W3C propagation only,
+// "stdout" and "file" write spans as JSON for local debugging.
+type TracingConfig struct {
+	Enabled     bool    `env:"ENABLED" envDefault:"false"`
+	Exporter    string  `env:"EXPORTER" envDefault:"none"`
+	FilePath    string  `env:"FILE_PATH" envDefault:"traces.json"`
+	SampleRatio float64 `env:"SAMPLE_RATIO" envDefault:"1"`
+}
+
+// AuditConfig selects the audit sinks: OUTPUT is none, stdout, stderr or file,
+// MONGO_ENABLED additionally stores events in MONGO_COLLECTION, removed after
+// RETENTION (0 keeps them forever).
+type AuditConfig struct {
+	Output          string        `env:"OUTPUT" envDefault:"stdout"`
+	FilePath        string        `env:"FILE_PATH" envDefault:"/var/log/app/audit.log"`
+	MongoEnabled    bool          `env:"MONGO_ENABLED" envDefault:"false"`
+	MongoCollection string        `env:"MONGO_COLLECTION" envDefault:"audit_events"`
+	Retention       time.Duration `env:"RETENTION" envDefault:"2160h"`
+}
+
+// LogPayloadConfig controls payload logging in the gRPC logging interceptors.
+// When ENABLED is not set, payloads are logged outside of production only.
+type LogPayloadConfig struct {
+	Enabled      *bool           `env:"ENABLED"`
+	MaxBytes     int             `env:"MAX_BYTES" envDefault:"4096"`
+	RedactFields []string        `env:"REDACT_FIELDS" envSeparator:","`
+	Level        logger.LogLevel `env:"LEVEL" envDefault:"TRACE"`
+}
+
+func (c *LogPayloadConfig) IsEnabled(appEnv AppEnv) bool {
+	if c.Enabled != nil {
+		return *c.Enabled
+	}
+
+	return appEnv != AppEnvProduction
+}
+
+// LogFileConfig configures the file output. The file rotates at MAX_SIZE_MB
+// and every ROTATE_INTERVAL (0 disables time-based rotation); MAX_AGE_DAYS and
+// MAX_BACKUPS only prune rotated files.
+type LogFileConfig struct {
+	Path           string        `env:"PATH" envDefault:"/var/log/app/app.log"`
+	MaxSizeMB      int           `env:"MAX_SIZE_MB" envDefault:"100"`
+	MaxAgeDays     int           `env:"MAX_AGE_DAYS" envDefault:"7"`
+	MaxBackups     int           `env:"MAX_BACKUPS" envDefault:"5"`
+	Compress       bool          `env:"COMPRESS" envDefault:"false"`
+	RotateInterval time.Duration `env:"ROTATE_INTERVAL" envDefault:"0"`
+}
+
+type LogSamplingConfig struct {
+	Enabled    bool          `env:"ENABLED" envDefault:"false"`
+	Initial    int           `env:"INITIAL" envDefault:"10"`
+	Thereafter int           `env:"THEREAFTER" envDefault:"100"`
+	Interval   time.Duration `env:"INTERVAL" envDefault:"1m"`
+}
+
+// LogSlowCallConfig: METHODS overrides THRESHOLD per full method or service,
+// e.g. "/wiki.v1.WikiReadService/Search=3s,identity.v1.IdentityPublicService=500ms".
+// A zero threshold disables slow-call logging.
+type LogSlowCallConfig struct {
+	Threshold time.Duration           `env:"THRESHOLD" envDefault:"1s"`
+	Methods   logger.MethodThresholds `env:"METHODS"`
+}
+
+// LogProxyConfig decides whose x-forwarded-for and x-real-ip metadata is used
+// for peer_ip in the gRPC server logs. TRUST requires TRUSTED_CIDRS (CIDRs or
+// addresses of the proxies in front of the service).
+type LogProxyConfig struct {
+	Trust        bool     `env:"TRUST" envDefault:"false"`
+	TrustedCIDRs []string `env:"TRUSTED_CIDRS" envSeparator:","`
+}
+
 type AppConfig struct {
-	AppEnv               AppEnv          `env:"APP_ENV" envDefault:"dev"`
-	LogLevel             logger.LogLevel `env:"APP_LOG_LEVEL" envDefault:"INFO"`
-	ServiceHealthTimeout time.Duration   `env:"SERVICE_HEALTH_TIMEOUT" envDefault:"60s"`
-	ServiceName          string          `env:"SERVICE_NAME" envDefault:""`
-	ServiceVersion       string          `env:"SERVICE_VERSION" envDefault:""`
+	AppEnv                 AppEnv          `env:"APP_ENV" envDefault:"dev"`
+	LogLevel               logger.LogLevel `env:"APP_LOG_LEVEL" envDefault:"INFO"`
+	LogFormat              logger.Format   `env:"APP_LOG_FORMAT" envDefault:"json"`
+	LogOutput              []logger.Output `env:"APP_LOG_OUTPUT" envDefault:"stdout" envSeparator:","`
+	ServiceHealthTimeout   time.Duration   `env:"SERVICE_HEALTH_TIMEOUT" envDefault:"60s"`
+	ServiceShutdownTimeout time.Duration   `env:"SERVICE_SHUTDOWN_TIMEOUT" envDefault:"30s"`
+	ServiceDrainDelay      time.Duration   `env:"SERVICE_DRAIN_DELAY" envDefault:"0s"`
+	ServiceName            string          `env:"SERVICE_NAME" envDefault:""`
+	ServiceVersion         string          `env:"SERVICE_VERSION" envDefault:""`
+	ServiceVersionFile     string          `env:"SERVICE_VERSION_FILE" envDefault:"/app/version.txt"`
+	ServiceInstanceID      string          `env:"SERVICE_INSTANCE_ID" envDefault:""`
+
+	LogLevels   logger.ScopeLevels `env:"APP_LOG_LEVELS"`
+	LogFile     LogFileConfig      `envPrefix:"APP_LOG_FILE_"`
+	LogPayload  LogPayloadConfig   `envPrefix:"APP_LOG_PAYLOAD_"`
+	LogSampling LogSamplingConfig  `envPrefix:"APP_LOG_SAMPLING_"`
+	LogSlowCall LogSlowCallConfig  `envPrefix:"APP_LOG_SLOW_"`
+	LogProxy    LogProxyConfig     `envPrefix:"APP_LOG_PROXY_"`
 
 	GRPC      GRPCServerConfig    `envPrefix:"GRPC_"`
 	HTTP      HTTPServerConfig    `envPrefix:"HTTP_"`
@@ -136,8 +277,13 @@ type AppConfig struct {
 	OAuth     OAuthConfig         `envPrefix:"OAUTH_"`
 	RateLimit RateLimitConfig     `envPrefix:"RATE_LIMIT_"`
 	Mongo     MongoConfig         `envPrefix:"MONGO_"`
+	Tracing   TracingConfig       `envPrefix:"TRACING_"`
+	Audit     AuditConfig         `envPrefix:"AUDIT_"`
 
 	GRPCServices []*GRPCService `env:"-"`
+
+	// Service is resolved by LoadConfig from the SERVICE_* and APP_ENV values.
+	Service serviceinfo.Info `env:"-"`
 }
 
 type AppConfigProvider interface {
@@ -150,6 +296,9 @@ type AppConfigProvider interface {
 	GetHealthConfig() *HealthServerConfig
 	GetMetricsConfig() *MetricsServerConfig
 	GetMongoConfig() *MongoConfig
+	GetTracingConfig() *TracingConfig
+	GetAuditConfig() *AuditConfig
+	GetServiceInfo() *serviceinfo.Info
 	GetGRPCServices() []*GRPCService
 }
 
@@ -189,69 +338,120 @@ func (p *AppConfig) GetMongoConfig() *MongoConfig {
 	return &p.Mongo
 }
 
+func (p *AppConfig) GetTracingConfig() *TracingConfig {
+	return &p.Tracing
+}
+
+func (p *AppConfig) GetAuditConfig() *AuditConfig {
+	return &p.Audit
+}
+
+// GetServiceInfo returns Service as resolved by LoadConfig. Configs that were
+// not built by LoadConfig, e.g. in tests, get a freshly resolved copy; Service
+// itself is never written here, so concurrent callers do not race.
+func (p *AppConfig) GetServiceInfo() *serviceinfo.Info {
+	if p.Service.Name == "" {
+		info := serviceinfo.Resolve(p.serviceSource())
+		return &info
+	}
+
+	return &p.Service
+}
+
+func (p *AppConfig) serviceSource() serviceinfo.Source {
+	return serviceinfo.Source{
+		Name:        p.ServiceName,
+		Version:     p.ServiceVersion,
+		Env:         string(p.AppEnv),
+		VersionFile: p.ServiceVersionFile,
+		InstanceID:  p.ServiceInstanceID,
+	}
+}
+
+// logPayloadEnabled uses the resolved (trimmed, lowercased) env, so that
+// APP_ENV=" PROD " disables payloads like APP_ENV=prod.
+func (p *AppConfig) logPayloadEnabled() bool {
+	return p.LogPayload.IsEnabled(AppEnv(p.GetServiceInfo().Env))
+}
+
 func (p *AppConfig) GetGRPCServices() []*GRPCService {
 	return p.GRPCServices
 }
 
+type grpcServiceRegistration struct {
+	Name            string
+	EnvPrefix       string
+	RegisterEntries []RegisterEntry
+}
+
+func (r grpcServiceRegistration) addressEnv() string {
+	return r.EnvPrefix + "ENDPOINT"
+}
+
 var (
-	once                sync.Once
-	configLoadingErr    error
-	instance            *AppConfig
-	loggerEntry         = logrus.WithField("scope", "config")
-	grpcServiceRegistry = map[string]struct {
-		AddressEnv      string
-		RegisterEntries []RegisterEntry
-	}{
-		"IdentityService": {
-			AddressEnv: "IDENTITY_SERVICE_ENDPOINT",
-			RegisterEntries: []RegisterEntry{
-				{
-					HandlerName:         "IdentityPublicServiceHandler",
-					HandlerRegisterFunc: identity_v1.RegisterIdentityPublicServiceHandler,
-				}, {
-					HandlerName:         "IdentityInternalServiceHandler",
-					HandlerRegisterFunc: identity_v1.RegisterIdentityInternalServiceHandler,
-				},
+	once             sync.Once
+	configLoadingErr error
+	instance         *AppConfig
+	loggerEntry      = logrus.WithField("scope", "config")
+)
+
+// grpcServiceRegistry is a slice (not a map) so that LoadConfig discovers
+// services in the declared order.
+var grpcServiceRegistry = []grpcServiceRegistration{
+	{
+		Name:      "IdentityService",
+		EnvPrefix: "IDENTITY_SERVICE_",
+		RegisterEntries: []RegisterEntry{
+			{
+				HandlerName:         "IdentityPublicServiceHandler",
+				HandlerRegisterFunc: identity_v1.RegisterIdentityPublicServiceHandler,
+			}, {
+				HandlerName:         "IdentityInternalServiceHandler",
+				HandlerRegisterFunc: identity_v1.RegisterIdentityInternalServiceHandler,
 			},
 		},
-		"WikiReadService": {
-			AddressEnv: "WIKI_READ_SERVICE_ENDPOINT",
-			RegisterEntries: []RegisterEntry{
-				{
-					HandlerName:         "WikiReadServiceHandler",
-					HandlerRegisterFunc: wiki_v1.RegisterWikiReadServiceHandler,
-				},
+	},
+	{
+		Name:      "WikiReadService",
+		EnvPrefix: "WIKI_READ_SERVICE_",
+		RegisterEntries: []RegisterEntry{
+			{
+				HandlerName:         "WikiReadServiceHandler",
+				HandlerRegisterFunc: wiki_v1.RegisterWikiReadServiceHandler,
 			},
 		},
-		"WikiWriteService": {
-			AddressEnv: "WIKI_WRITE_SERVICE_ENDPOINT",
-			RegisterEntries: []RegisterEntry{
-				{
-					HandlerName:         "WikiWriteServiceHandler",
-					HandlerRegisterFunc: wiki_v1.RegisterWikiWriteServiceHandler,
-				},
+	},
+	{
+		Name:      "WikiWriteService",
+		EnvPrefix: "WIKI_WRITE_SERVICE_",
+		RegisterEntries: []RegisterEntry{
+			{
+				HandlerName:         "WikiWriteServiceHandler",
+				HandlerRegisterFunc: wiki_v1.RegisterWikiWriteServiceHandler,
 			},
 		},
-		"MediaService": {
-			AddressEnv: "MEDIA_SERVICE_ENDPOINT",
-			RegisterEntries: []RegisterEntry{
-				{
-					HandlerName:         "MediaServiceHandler",
-					HandlerRegisterFunc: media_v1.RegisterMediaServiceHandler,
-				},
+	},
+	{
+		Name:      "MediaService",
+		EnvPrefix: "MEDIA_SERVICE_",
+		RegisterEntries: []RegisterEntry{
+			{
+				HandlerName:         "MediaServiceHandler",
+				HandlerRegisterFunc: media_v1.RegisterMediaServiceHandler,
 			},
 		},
-		"SearchService": {
-			AddressEnv: "SEARCH_SERVICE_ENDPOINT",
-			RegisterEntries: []RegisterEntry{
-				{
-					HandlerName:         "SearchServiceHandler",
-					HandlerRegisterFunc: search_v1.RegisterSearchServiceHandler,
-				},
+	},
+	{
+		Name:      "SearchService",
+		EnvPrefix: "SEARCH_SERVICE_",
+		RegisterEntries: []RegisterEntry{
+			{
+				HandlerName:         "SearchServiceHandler",
+				HandlerRegisterFunc: search_v1.RegisterSearchServiceHandler,
 			},
 		},
-	}
-)
+	},
+}
 
 func LoadConfig() (*AppConfig, error) {
 	var (
@@ -263,61 +463,100 @@ func LoadConfig() (*AppConfig, error) {
 		return nil, fmt.Errorf("failed to parse server config: %w", err)
 	}
 
-	if cfg.ServiceVersion == "" {
-		if version, err := ReadServiceVersion(); err == nil {
-			cfg.ServiceVersion = version
-		}
+	cfg.Service = serviceinfo.Resolve(cfg.serviceSource())
+
+	if cfg.ServiceVersion == "" && cfg.Service.Version != serviceinfo.Unknown {
+		cfg.ServiceVersion = cfg.Service.Version
 	}
 
-	serviceName := cfg.ServiceName
-	if serviceName == "" {
-		serviceName = "unknown"
+	logCfg := logger.Config{
+		Level:       cfg.LogLevel,
+		ScopeLevels: cfg.LogLevels,
+		Env:         cfg.Service.Env,
+		Service:     cfg.Service.Name,
+		Version:     cfg.Service.Version,
+		Format:      cfg.LogFormat,
+		Output:      cfg.LogOutput,
+		File: logger.FileConfig{
+			Path:           cfg.LogFile.Path,
+			MaxSizeMB:      cfg.LogFile.MaxSizeMB,
+			MaxAgeDays:     cfg.LogFile.MaxAgeDays,
+			MaxBackups:     cfg.LogFile.MaxBackups,
+			Compress:       cfg.LogFile.Compress,
+			RotateInterval: cfg.LogFile.RotateInterval,
+		},
+		Payload: logger.PayloadConfig{
+			Enabled:      cfg.logPayloadEnabled(),
+			MaxBytes:     cfg.LogPayload.MaxBytes,
+			RedactFields: cfg.LogPayload.RedactFields,
+			Level:        cfg.LogPayload.Level,
+		},
+		Sampling: logger.SamplingConfig{
+			Enabled:    cfg.LogSampling.Enabled,
+			Initial:    cfg.LogSampling.Initial,
+			Thereafter: cfg.LogSampling.Thereafter,
+			Interval:   cfg.LogSampling.Interval,
+		},
+		SlowCall: logger.SlowCallConfig{
+			Threshold: cfg.LogSlowCall.Threshold,
+			Methods:   cfg.LogSlowCall.Methods,
+		},
+		Proxy: logger.ProxyConfig{
+			Trust:        cfg.LogProxy.Trust,
+			TrustedCIDRs: cfg.LogProxy.TrustedCIDRs,
+		},
 	}
-	serviceVersion := cfg.ServiceVersion
-	if serviceVersion == "" {
-		serviceVersion = "unknown"
+
+	if err := logCfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid log config: %w", err)
 	}
 
-	logger.Init(logger.Config{
-		Level:   cfg.LogLevel,
-		Env:     string(cfg.AppEnv),
-		Service: serviceName,
-		Version: serviceVersion,
-	})
+	logger.Init(logCfg)
 
-	for servicePrefix, registrationInfo := range grpcServiceRegistry {
-		address := os.Getenv(registrationInfo.AddressEnv)
+	for _, registrationInfo := range grpcServiceRegistry {
+		address := os.Getenv(registrationInfo.addressEnv())
 
 		if address == "" {
 			loggerEntry.Debugf(
 				"gRPC service address not configured for '%s' (env var: %s), skipping...",
-				servicePrefix,
-				registrationInfo.AddressEnv,
+				registrationInfo.Name,
+				registrationInfo.addressEnv(),
 			)
 
 			continue
 		}
 
 		if len(registrationInfo.RegisterEntries) == 0 {
-			return nil, fmt.Errorf("internal config error: register function is not specified for service '%s'", servicePrefix)
+			return nil, fmt.Errorf("internal config error: register function is not specified for service '%s'", registrationInfo.Name)
 		} else {
 			for _, registerEntry := range registrationInfo.RegisterEntries {
 				if registerEntry.HandlerRegisterFunc == nil {
 					return nil, fmt.Errorf(
 						"internal config error: register function '%s' is nil for service '%s'",
 						registerEntry.HandlerName,
-						servicePrefix,
+						registrationInfo.Name,
 					)
 				}
 			}
 		}
 
-		loggerEntry.Infof("found gRPC service '%s' at address: %s", servicePrefix, address)
+		var clientCfg GRPCClientConfig
+
+		if err := env.ParseWithOptions(&clientCfg, env.Options{Prefix: registrationInfo.EnvPrefix}); err != nil {
+			return nil, fmt.Errorf("failed to parse client config for service '%s': %w", registrationInfo.Name, err)
+		}
+
+		if err := clientCfg.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid client config for service '%s': %w", registrationInfo.Name, err)
+		}
+
+		loggerEntry.Infof("found gRPC service '%s' at address: %s", registrationInfo.Name, address)
 
 		loadedServices = append(loadedServices, &GRPCService{
-			Name:            servicePrefix,
+			Name:            registrationInfo.Name,
 			Address:         address,
 			RegisterEntries: registrationInfo.RegisterEntries,
+			Client:          clientCfg,
 		})
 	}
 
@@ -330,6 +569,7 @@ func LoadConfig() (*AppConfig, error) {
 	loggerEntry.Info("configuration loaded successfully")
 
 	loggerEntry.Debugf("AppEnv: '%s'", cfg.AppEnv)
+	loggerEntry.Debugf("Service: '%s', Version: '%s', InstanceID: '%s'", cfg.Service.Name, cfg.Service.Version, cfg.Service.InstanceID)
 	loggerEntry.Debugf("LogLevel: '%s'", cfg.LogLevel)
 	loggerEntry.Debugf("GRPC Host: %s, Port: %s", cfg.GRPC.Host, cfg.GRPC.Port)
 	loggerEntry.Debugf("HTTP Host: %s, Port: %s", cfg.HTTP.Host, cfg.HTTP.Port)
@@ -341,7 +581,14 @@ func LoadConfig() (*AppConfig, error) {
 	}
 
 	for _, svc := range cfg.GRPCServices {
-		loggerEntry.Debugf("gRPC service: Name='%s', Address='%s'", svc.Name, svc.Address)
+		loggerEntry.Debugf(
+			"gRPC service: Name='%s', Address='%s', Timeout=%s, TLS=%t, LBPolicy='%s'",
+			svc.Name,
+			svc.Address,
+			svc.Client.Timeout,
+			svc.Client.TLS.Enabled,
+			svc.Client.LoadBalancingPolicy,
+		)
 	}
 
 	return &cfg, nil
@@ -358,26 +605,10 @@ func Config() (AppConfigProvider, error) {
 	return instance, configLoadingErr
 }
 
+// ReadServiceVersion reads the version from serviceinfo.DefaultVersionFile.
+//
+// Deprecated: use serviceinfo.ReadVersionFile, which also honours SERVICE_VERSION_FILE
+// through serviceinfo.Resolve.
 func ReadServiceVersion() (string, error) {
-	const maxVersionLength = 128
-
-	path := filepath.FromSlash("/app/version.txt")
-
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return "", err
-	}
-
-	version := string(data)
-	version = strings.TrimSpace(version)
-
-	if version == "" {
-		return "", fmt.Errorf("service version file is empty")
-	}
-
-	if len(version) > maxVersionLength {
-		return "", fmt.Errorf("service version length exceeds limit")
-	}
-
-	return version, nil
+	return serviceinfo.ReadVersionFile(serviceinfo.DefaultVersionFile)
 }
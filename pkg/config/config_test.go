@@ -0,0 +1,262 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLoadConfigKeepsGRPCServicesOrder(t *testing.T) {
+	for _, registration := range grpcServiceRegistry {
+		t.Setenv(registration.addressEnv(), registration.Name+":8080")
+	}
+
+	for i := 0; i < 20; i++ {
+		cfg, err := LoadConfig()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(cfg.GRPCServices) != len(grpcServiceRegistry) {
+			t.Fatalf("expected %d services, got %d", len(grpcServiceRegistry), len(cfg.GRPCServices))
+		}
+
+		for j, svc := range cfg.GRPCServices {
+			if svc.Name != grpcServiceRegistry[j].Name {
+				t.Fatalf("expected service %d to be %s, got %s", j, grpcServiceRegistry[j].Name, svc.Name)
+			}
+		}
+	}
+}
+
+func TestLoadConfigSkipsUnconfiguredGRPCServices(t *testing.T) {
+	for _, registration := range grpcServiceRegistry {
+		t.Setenv(registration.addressEnv(), "")
+	}
+
+	t.Setenv("SEARCH_SERVICE_ENDPOINT", "searchservice:8080")
+	t.Setenv("IDENTITY_SERVICE_ENDPOINT", "identityservice:8080")
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(cfg.GRPCServices) != 2 {
+		t.Fatalf("expected 2 services, got %d", len(cfg.GRPCServices))
+	}
+
+	if cfg.GRPCServices[0].Name != "IdentityService" || cfg.GRPCServices[1].Name != "SearchService" {
+		t.Fatalf("unexpected order: %s, %s", cfg.GRPCServices[0].Name, cfg.GRPCServices[1].Name)
+	}
+}
+
+func TestLoadConfigParsesPerServiceClientConfig(t *testing.T) {
+	for _, registration := range grpcServiceRegistry {
+		t.Setenv(registration.addressEnv(), "")
+	}
+
+	t.Setenv("WIKI_READ_SERVICE_ENDPOINT", "dns:///wikireadservice:8080")
+	t.Setenv("WIKI_READ_SERVICE_TIMEOUT", "3s")
+	t.Setenv("WIKI_READ_SERVICE_LB_POLICY", "round_robin")
+	t.Setenv("WIKI_READ_SERVICE_TLS_ENABLED", "true")
+	t.Setenv("WIKI_READ_SERVICE_TLS_SERVER_NAME", "wiki.internal")
+	t.Setenv("WIKI_READ_SERVICE_KEEPALIVE_TIME", "30s")
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(cfg.GRPCServices) != 1 {
+		t.Fatalf("expected 1 service, got %d", len(cfg.GRPCServices))
+	}
+
+	client := cfg.GRPCServices[0].Client
+
+	if client.Timeout != 3*time.Second {
+		t.Fatalf("expected timeout 3s, got %s", client.Timeout)
+	}
+
+	if client.LoadBalancingPolicy != "round_robin" {
+		t.Fatalf("expected round_robin, got %s", client.LoadBalancingPolicy)
+	}
+
+	if !client.TLS.Enabled || client.TLS.ServerName != "wiki.internal" {
+		t.Fatalf("unexpected TLS config: %+v", client.TLS)
+	}
+
+	if client.Keepalive.Time != 30*time.Second || client.Keepalive.Timeout != 20*time.Second {
+		t.Fatalf("unexpected keepalive config: %+v", client.Keepalive)
+	}
+}
+
+func TestLoadConfigRejectsInvalidServiceConfig(t *testing.T) {
+	for _, registration := range grpcServiceRegistry {
+		t.Setenv(registration.addressEnv(), "")
+	}
+
+	t.Setenv("MEDIA_SERVICE_ENDPOINT", "mediaservice:8080")
+	t.Setenv("MEDIA_SERVICE_SERVICE_CONFIG", "{not json")
+
+	if _, err := LoadConfig(); err == nil {
+		t.Fatalf("expected error for invalid service config")
+	}
+}
+
+func TestLoadConfigPayloadLoggingDefaultsByEnv(t *testing.T) {
+	cases := []struct {
+		appEnv   string
+		enabled  string
+		expected bool
+	}{
+		{appEnv: "dev", enabled: "", expected: true},
+		{appEnv: "prod", enabled: "", expected: false},
+		{appEnv: "prod", enabled: "true", expected: true},
+		{appEnv: "dev", enabled: "false", expected: false},
+		{appEnv: " PROD ", enabled: "", expected: false},
+	}
+
+	for _, tc := range cases {
+		t.Setenv("APP_ENV", tc.appEnv)
+		t.Setenv("APP_LOG_PAYLOAD_ENABLED", tc.enabled)
+
+		cfg, err := LoadConfig()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if got := cfg.logPayloadEnabled(); got != tc.expected {
+			t.Fatalf("APP_ENV=%s APP_LOG_PAYLOAD_ENABLED=%q: expected %v, got %v", tc.appEnv, tc.enabled, tc.expected, got)
+		}
+	}
+}
+
+func TestLoadConfigParsesScopeLogLevels(t *testing.T) {
+	t.Setenv("APP_LOG_LEVELS", "gRPC=trace,health=warn")
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.LogLevels["gRPC"] != "TRACE" || cfg.LogLevels["health"] != "WARN" {
+		t.Fatalf("unexpected scope levels: %v", cfg.LogLevels)
+	}
+
+	t.Setenv("APP_LOG_LEVELS", "gRPC=loud")
+
+	if _, err := LoadConfig(); err == nil {
+		t.Fatalf("expected unknown scope level to be rejected")
+	}
+}
+
+func TestLoadConfigResolvesServiceInfo(t *testing.T) {
+	versionFile := filepath.Join(t.TempDir(), "version.txt")
+	if err := os.WriteFile(versionFile, []byte("1.4.0\n"), 0o600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Setenv("SERVICE_NAME", "wiki")
+	t.Setenv("SERVICE_VERSION", "")
+	t.Setenv("SERVICE_VERSION_FILE", versionFile)
+	t.Setenv("SERVICE_INSTANCE_ID", "wiki-0")
+	t.Setenv("APP_ENV", "prod")
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	info := cfg.GetServiceInfo()
+	if info.Name != "wiki" || info.Version != "1.4.0" || info.Env != "prod" || info.InstanceID != "wiki-0" {
+		t.Fatalf("unexpected service info: %+v", info)
+	}
+
+	if cfg.ServiceVersion != "1.4.0" {
+		t.Fatalf("expected ServiceVersion from the version file, got %q", cfg.ServiceVersion)
+	}
+}
+
+func TestGetServiceInfoDoesNotWriteConfig(t *testing.T) {
+	cfg := &AppConfig{ServiceName: "wiki", AppEnv: "prod"}
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if info := cfg.GetServiceInfo(); info.Name != "wiki" || info.Env != "prod" {
+				t.Errorf("unexpected service info: %+v", info)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if cfg.Service.Name != "" {
+		t.Fatalf("expected Service to be left to LoadConfig, got %+v", cfg.Service)
+	}
+}
+
+func TestLoadConfigParsesSlowCallThresholds(t *testing.T) {
+	t.Setenv("APP_LOG_SLOW_THRESHOLD", "2s")
+	t.Setenv("APP_LOG_SLOW_METHODS", "/wiki.v1.WikiReadService/Search=5s,identity.v1.IdentityPublicService=500ms")
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.LogSlowCall.Threshold != 2*time.Second {
+		t.Fatalf("expected 2s threshold, got %s", cfg.LogSlowCall.Threshold)
+	}
+
+	if cfg.LogSlowCall.Methods["/wiki.v1.WikiReadService/Search"] != 5*time.Second ||
+		cfg.LogSlowCall.Methods["identity.v1.IdentityPublicService"] != 500*time.Millisecond {
+		t.Fatalf("unexpected method thresholds: %v", cfg.LogSlowCall.Methods)
+	}
+
+	t.Setenv("APP_LOG_SLOW_METHODS", "/wiki.v1.WikiReadService/Search=soon")
+
+	if _, err := LoadConfig(); err == nil {
+		t.Fatalf("expected error for invalid threshold")
+	}
+}
+
+func TestLoadConfigTracingExporterDefaultsToNone(t *testing.T) {
+	t.Setenv("TRACING_ENABLED", "true")
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.Tracing.Exporter != "none" {
+		t.Fatalf("expected exporter none, got %q", cfg.Tracing.Exporter)
+	}
+}
+
+func TestLoadConfigRejectsProxyTrustWithoutCIDRs(t *testing.T) {
+	t.Setenv("APP_LOG_PROXY_TRUST", "true")
+
+	if _, err := LoadConfig(); err == nil {
+		t.Fatalf("expected error for APP_LOG_PROXY_TRUST without APP_LOG_PROXY_TRUSTED_CIDRS")
+	}
+
+	t.Setenv("APP_LOG_PROXY_TRUSTED_CIDRS", "10.0.0.0/8, 192.168.1.10")
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(cfg.LogProxy.TrustedCIDRs) != 2 {
+		t.Fatalf("expected 2 trusted proxies, got %v", cfg.LogProxy.TrustedCIDRs)
+	}
+}
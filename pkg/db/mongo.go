@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/invenlore/core/pkg/config"
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
@@ -31,3 +32,46 @@ func MongoDBConnect(ctx context.Context, mongoCfg *config.MongoConfig, opts ...*
 
 	return client, nil
 }
+
+// MergeCommandMonitors combines monitors such as metrics.MongoMetrics.Monitor() and
+// tracing.MongoTracing.Monitor() for options.Client().SetMonitor. Nil monitors are skipped.
+func MergeCommandMonitors(monitors ...*event.CommandMonitor) *event.CommandMonitor {
+	var active []*event.CommandMonitor
+
+	for _, m := range monitors {
+		if m != nil {
+			active = append(active, m)
+		}
+	}
+
+	switch len(active) {
+	case 0:
+		return nil
+	case 1:
+		return active[0]
+	}
+
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, ev *event.CommandStartedEvent) {
+			for _, m := range active {
+				if m.Started != nil {
+					m.Started(ctx, ev)
+				}
+			}
+		},
+		Succeeded: func(ctx context.Context, ev *event.CommandSucceededEvent) {
+			for _, m := range active {
+				if m.Succeeded != nil {
+					m.Succeeded(ctx, ev)
+				}
+			}
+		},
+		Failed: func(ctx context.Context, ev *event.CommandFailedEvent) {
+			for _, m := range active {
+				if m.Failed != nil {
+					m.Failed(ctx, ev)
+				}
+			}
+		},
+	}
+}
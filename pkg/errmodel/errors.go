@@ -22,7 +22,7 @@ func RequestIDFromContext(ctx context.Context) string {
 		return ""
 	}
 
-	if requestID, ok := ctx.Value(logger.RequestIDCtxKey).(string); ok && requestID != "" {
+	if requestID := logger.RequestIDFromContext(ctx); requestID != "" {
 		return requestID
 	}
 
@@ -0,0 +1,168 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/textproto"
+
+	"github.com/google/uuid"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/invenlore/core/pkg/config"
+	"github.com/invenlore/core/pkg/errmodel"
+	"github.com/invenlore/core/pkg/grpcclient"
+	"github.com/invenlore/core/pkg/logger"
+	"github.com/invenlore/core/pkg/tracing"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+var requestIDHeader = textproto.CanonicalMIMEHeaderKey(logger.RequestIDMDKey)
+
+// NewServeMux builds a runtime.ServeMux that forwards X-Request-Id to gRPC metadata, sets
+// x-real-ip to the address of the HTTP peer and maps errors through errmodel.
+// Extra options are applied after the defaults.
+func NewServeMux(opts ...runtime.ServeMuxOption) *runtime.ServeMux {
+	defaults := []runtime.ServeMuxOption{
+		runtime.WithIncomingHeaderMatcher(incomingHeaderMatcher),
+		runtime.WithMetadata(realIPMetadata),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeaderMatcher),
+		runtime.WithErrorHandler(ErrorHandler),
+	}
+
+	return runtime.NewServeMux(append(defaults, opts...)...)
+}
+
+// NewHandler dials every service through pool, registers all of its entries on a new
+// ServeMux and wraps the result with RequestIDMiddleware and tracing.HTTPMiddleware.
+func NewHandler(ctx context.Context, pool *grpcclient.Pool, services []*config.GRPCService, opts ...runtime.ServeMuxOption) (http.Handler, error) {
+	mux := NewServeMux(opts...)
+
+	if err := Register(ctx, mux, pool, services); err != nil {
+		return nil, err
+	}
+
+	return RequestIDMiddleware(tracing.HTTPMiddleware(mux)), nil
+}
+
+func Register(ctx context.Context, mux *runtime.ServeMux, pool *grpcclient.Pool, services []*config.GRPCService) error {
+	loggerEntry := logrus.WithField("scope", "gateway")
+
+	for _, svc := range services {
+		conn, err := pool.Conn(ctx, svc)
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range svc.RegisterEntries {
+			if entry.HandlerRegisterFunc == nil {
+				return fmt.Errorf("register function '%s' is nil for service '%s'", entry.HandlerName, svc.Name)
+			}
+
+			if err := entry.HandlerRegisterFunc(ctx, mux, conn); err != nil {
+				return fmt.Errorf("failed to register '%s' for service '%s': %w", entry.HandlerName, svc.Name, err)
+			}
+
+			loggerEntry.Infof("registered handler '%s' for gRPC service '%s'", entry.HandlerName, svc.Name)
+		}
+	}
+
+	return nil
+}
+
+// RequestIDMiddleware makes sure every request carries an X-Request-Id header,
+// stores it in the request context and echoes it in the response.
+func RequestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(requestIDHeader)
+		if requestID == "" {
+			requestID = uuid.NewString()
+			r.Header.Set(requestIDHeader, requestID)
+		}
+
+		w.Header().Set(requestIDHeader, requestID)
+
+		ctx := logger.WithRequestID(r.Context(), requestID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// ErrorHandler makes sure every error response is a status built by errmodel,
+// i.e. carries the request ID, before delegating to runtime.DefaultHTTPErrorHandler.
+func ErrorHandler(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	var httpStatusErr *runtime.HTTPStatusError
+	if errors.As(err, &httpStatusErr) {
+		runtime.DefaultHTTPErrorHandler(ctx, mux, marshaler, w, r, err)
+		return
+	}
+
+	runtime.DefaultHTTPErrorHandler(ctx, mux, marshaler, w, r, toErrmodel(ctx, err))
+}
+
+func toErrmodel(ctx context.Context, err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		st = status.FromContextError(err)
+
+		if st.Code() == codes.Unknown {
+			logrus.WithFields(logrus.Fields{
+				"scope":      "gateway",
+				"request_id": errmodel.RequestIDFromContext(ctx),
+			}).WithError(err).Error("unexpected gateway error")
+
+			return errmodel.Error(ctx, codes.Internal, "internal error")
+		}
+	}
+
+	details := make([]proto.Message, 0, len(st.Details()))
+
+	for _, detail := range st.Details() {
+		msg, ok := detail.(proto.Message)
+		if !ok {
+			continue
+		}
+
+		if _, ok := msg.(*errdetails.RequestInfo); ok {
+			return st.Err()
+		}
+
+		details = append(details, msg)
+	}
+
+	return errmodel.Error(ctx, st.Code(), st.Message(), details...)
+}
+
+func incomingHeaderMatcher(key string) (string, bool) {
+	switch textproto.CanonicalMIMEHeaderKey(key) {
+	case requestIDHeader:
+		return logger.RequestIDMDKey, true
+	}
+
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// realIPMetadata sets x-real-ip from the connection instead of a client header,
+// so it cannot be forged. The server logs only use it when the gateway is a
+// trusted proxy (see logger.ProxyConfig).
+func realIPMetadata(_ context.Context, r *http.Request) metadata.MD {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil || host == "" {
+		return nil
+	}
+
+	return metadata.Pairs("x-real-ip", host)
+}
+
+func outgoingHeaderMatcher(key string) (string, bool) {
+	if textproto.CanonicalMIMEHeaderKey(key) == requestIDHeader {
+		return "", false
+	}
+
+	return fmt.Sprintf("%s%s", runtime.MetadataHeaderPrefix, key), true
+}
@@ -0,0 +1,80 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/invenlore/core/pkg/logger"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRequestIDMiddlewareGeneratesAndEchoesID(t *testing.T) {
+	var seen string
+
+	handler := RequestIDMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = logger.RequestIDFromContext(r.Context())
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if seen == "" {
+		t.Fatalf("expected request id in context")
+	}
+
+	if got := rec.Header().Get("X-Request-Id"); got != seen {
+		t.Fatalf("expected response header %s, got %s", seen, got)
+	}
+}
+
+func TestErrorHandlerAddsRequestInfo(t *testing.T) {
+	ctx := logger.WithRequestID(context.Background(), "req-1")
+
+	err := toErrmodel(ctx, status.Error(codes.NotFound, "missing"))
+
+	st := status.Convert(err)
+	if st.Code() != codes.NotFound || st.Message() != "missing" {
+		t.Fatalf("unexpected status: %v", st)
+	}
+
+	var found bool
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.RequestInfo); ok && info.RequestId == "req-1" {
+			found = true
+		}
+	}
+
+	if !found {
+		t.Fatalf("expected RequestInfo detail")
+	}
+}
+
+func TestIncomingHeaderMatcherForwardsRequestID(t *testing.T) {
+	key, ok := incomingHeaderMatcher("x-request-id")
+	if !ok || key != logger.RequestIDMDKey {
+		t.Fatalf("expected x-request-id to be forwarded, got %q %v", key, ok)
+	}
+
+	if _, ok := incomingHeaderMatcher("X-Custom"); ok {
+		t.Fatalf("expected unrelated header to be dropped")
+	}
+}
+
+func TestRealIPComesFromTheConnection(t *testing.T) {
+	if _, ok := incomingHeaderMatcher("X-Real-Ip"); ok {
+		t.Fatalf("expected the client X-Real-Ip header to be dropped")
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "192.0.2.10:51234"
+	req.Header.Set("X-Real-Ip", "203.0.113.7")
+
+	md := realIPMetadata(context.Background(), req)
+	if got := md.Get("x-real-ip"); len(got) != 1 || got[0] != "192.0.2.10" {
+		t.Fatalf("expected x-real-ip from RemoteAddr, got %v", got)
+	}
+}
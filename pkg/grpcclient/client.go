@@ -0,0 +1,236 @@
+package grpcclient
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/invenlore/core/pkg/config"
+	"github.com/invenlore/core/pkg/logger"
+	"github.com/invenlore/core/pkg/metrics"
+	"github.com/invenlore/core/pkg/tracing"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+)
+
+type options struct {
+	metrics            *metrics.GRPCClientMetrics
+	unaryInterceptors  []grpc.UnaryClientInterceptor
+	streamInterceptors []grpc.StreamClientInterceptor
+	dialOptions        []grpc.DialOption
+	block              bool
+}
+
+type Option func(*options)
+
+// WithMetrics records grpc_client_* metrics for every call on the connection.
+func WithMetrics(m *metrics.GRPCClientMetrics) Option {
+	return func(o *options) {
+		o.metrics = m
+	}
+}
+
+// WithUnaryInterceptors appends interceptors after the core unary chain.
+func WithUnaryInterceptors(interceptors ...grpc.UnaryClientInterceptor) Option {
+	return func(o *options) {
+		o.unaryInterceptors = append(o.unaryInterceptors, interceptors...)
+	}
+}
+
+// WithStreamInterceptors appends interceptors after the core stream chain.
+func WithStreamInterceptors(interceptors ...grpc.StreamClientInterceptor) Option {
+	return func(o *options) {
+		o.streamInterceptors = append(o.streamInterceptors, interceptors...)
+	}
+}
+
+// WithDialOptions passes extra options to grpc.NewClient, after the ones built from config.
+func WithDialOptions(dialOptions ...grpc.DialOption) Option {
+	return func(o *options) {
+		o.dialOptions = append(o.dialOptions, dialOptions...)
+	}
+}
+
+// WithBlock makes Dial wait until the connection is ready or ctx is done.
+func WithBlock() Option {
+	return func(o *options) {
+		o.block = true
+	}
+}
+
+// Dial creates a client connection for svc using its client config.
+//
+// Unary chain (outermost first): request ID, tracing, logging, metrics, default timeout,
+// then interceptors from WithUnaryInterceptors.
+// The stream chain uses the stream counterparts in the same order, without the timeout.
+func Dial(ctx context.Context, svc *config.GRPCService, opts ...Option) (*grpc.ClientConn, error) {
+	if svc == nil {
+		return nil, fmt.Errorf("gRPC service is nil")
+	}
+
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	dialOptions, err := buildDialOptions(&svc.Client, o)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build dial options for service '%s': %w", svc.Name, err)
+	}
+
+	conn, err := grpc.NewClient(svc.Address, dialOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client for service '%s': %w", svc.Name, err)
+	}
+
+	logrus.WithField("scope", "gRPC").Debugf("client: created connection to service '%s' at %s", svc.Name, svc.Address)
+
+	if !o.block {
+		return conn, nil
+	}
+
+	if err := waitForReady(ctx, conn); err != nil {
+		_ = conn.Close()
+
+		return nil, fmt.Errorf("failed to connect to service '%s' at %s: %w", svc.Name, svc.Address, err)
+	}
+
+	return conn, nil
+}
+
+func buildDialOptions(cfg *config.GRPCClientConfig, o *options) ([]grpc.DialOption, error) {
+	creds, err := transportCredentials(&cfg.TLS)
+	if err != nil {
+		return nil, err
+	}
+
+	unary := []grpc.UnaryClientInterceptor{
+		logger.ClientRequestIDInterceptor,
+		tracing.ClientTracingInterceptor,
+		logger.ClientLoggingInterceptor,
+		o.metrics.UnaryClientInterceptor(),
+	}
+
+	if cfg.Timeout > 0 {
+		unary = append(unary, timeoutUnaryInterceptor(cfg.Timeout))
+	}
+
+	stream := []grpc.StreamClientInterceptor{
+		logger.ClientStreamRequestIDInterceptor,
+		tracing.ClientStreamTracingInterceptor,
+		logger.ClientStreamLoggingInterceptor,
+		o.metrics.StreamClientInterceptor(),
+	}
+
+	dialOptions := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithChainUnaryInterceptor(append(unary, o.unaryInterceptors...)...),
+		grpc.WithChainStreamInterceptor(append(stream, o.streamInterceptors...)...),
+	}
+
+	var callOptions []grpc.CallOption
+
+	if cfg.MaxRecvMsgSize > 0 {
+		callOptions = append(callOptions, grpc.MaxCallRecvMsgSize(cfg.MaxRecvMsgSize))
+	}
+
+	if cfg.MaxSendMsgSize > 0 {
+		callOptions = append(callOptions, grpc.MaxCallSendMsgSize(cfg.MaxSendMsgSize))
+	}
+
+	if len(callOptions) > 0 {
+		dialOptions = append(dialOptions, grpc.WithDefaultCallOptions(callOptions...))
+	}
+
+	if cfg.Keepalive.Time > 0 {
+		dialOptions = append(dialOptions, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                cfg.Keepalive.Time,
+			Timeout:             cfg.Keepalive.Timeout,
+			PermitWithoutStream: cfg.Keepalive.PermitWithoutStream,
+		}))
+	}
+
+	switch {
+	case cfg.ServiceConfig != "":
+		dialOptions = append(dialOptions, grpc.WithDefaultServiceConfig(cfg.ServiceConfig))
+	case cfg.LoadBalancingPolicy != "":
+		dialOptions = append(dialOptions, grpc.WithDefaultServiceConfig(
+			fmt.Sprintf(`{"loadBalancingConfig":[{%q:{}}]}`, cfg.LoadBalancingPolicy),
+		))
+	}
+
+	return append(dialOptions, o.dialOptions...), nil
+}
+
+func transportCredentials(cfg *config.GRPCClientTLSConfig) (credentials.TransportCredentials, error) {
+	if !cfg.Enabled {
+		return insecure.NewCredentials(), nil
+	}
+
+	tlsCfg := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         cfg.ServerName,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+
+	if cfg.CAFile != "" {
+		data, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("no certificates found in CA file %s", cfg.CAFile)
+		}
+
+		tlsCfg.RootCAs = pool
+	}
+
+	if cfg.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return credentials.NewTLS(tlsCfg), nil
+}
+
+// timeoutUnaryInterceptor applies a default deadline to calls that do not have one.
+func timeoutUnaryInterceptor(timeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if _, ok := ctx.Deadline(); ok {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+func waitForReady(ctx context.Context, conn *grpc.ClientConn) error {
+	conn.Connect()
+
+	for {
+		state := conn.GetState()
+		if state == connectivity.Ready {
+			return nil
+		}
+
+		if !conn.WaitForStateChange(ctx, state) {
+			return ctx.Err()
+		}
+	}
+}
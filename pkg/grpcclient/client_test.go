@@ -0,0 +1,143 @@
+package grpcclient
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/invenlore/core/pkg/config"
+	"github.com/invenlore/core/pkg/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func startBufServer(t *testing.T, interceptor grpc.UnaryServerInterceptor) *bufconn.Listener {
+	t.Helper()
+
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer(grpc.UnaryInterceptor(interceptor))
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	return lis
+}
+
+func bufDialer(lis *bufconn.Listener) grpc.DialOption {
+	return grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return lis.DialContext(ctx)
+	})
+}
+
+func TestDialAppliesRequestIDAndTimeout(t *testing.T) {
+	var (
+		gotRequestID string
+		gotDeadline  bool
+	)
+
+	lis := startBufServer(t, func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if vals := md.Get(logger.RequestIDMDKey); len(vals) > 0 {
+				gotRequestID = vals[0]
+			}
+		}
+
+		_, gotDeadline = ctx.Deadline()
+
+		return handler(ctx, req)
+	})
+
+	svc := &config.GRPCService{
+		Name:    "TestService",
+		Address: "passthrough:///bufnet",
+		Client:  config.GRPCClientConfig{Timeout: time.Second},
+	}
+
+	pool := NewPool(WithDialOptions(bufDialer(lis)))
+	defer pool.Close()
+
+	conn, err := pool.Conn(context.Background(), svc)
+	if err != nil {
+		t.Fatalf("unexpected dial error: %v", err)
+	}
+
+	if _, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{}); err != nil {
+		t.Fatalf("unexpected call error: %v", err)
+	}
+
+	if gotRequestID == "" {
+		t.Fatalf("expected request id metadata to be propagated")
+	}
+
+	if !gotDeadline {
+		t.Fatalf("expected default timeout to set a deadline")
+	}
+
+	again, err := pool.Conn(context.Background(), svc)
+	if err != nil || again != conn {
+		t.Fatalf("expected pooled connection to be reused")
+	}
+}
+
+func TestPoolRejectsConnAfterClose(t *testing.T) {
+	pool := NewPool()
+
+	if err := pool.Close(); err != nil {
+		t.Fatalf("unexpected close error: %v", err)
+	}
+
+	if _, err := pool.Conn(context.Background(), &config.GRPCService{Name: "x", Address: "localhost:1"}); err == nil {
+		t.Fatalf("expected error from closed pool")
+	}
+}
+
+func TestPoolDoesNotSerializeBlockingDials(t *testing.T) {
+	ready := startBufServer(t, func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(ctx, req)
+	})
+
+	// Nobody accepts on this listener, so a blocking dial waits for its ctx.
+	stuck := bufconn.Listen(1024 * 1024)
+	t.Cleanup(func() { _ = stuck.Close() })
+
+	dialer := grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+		if addr == "stuck" {
+			return stuck.DialContext(ctx)
+		}
+
+		return ready.DialContext(ctx)
+	})
+
+	pool := NewPool(WithBlock(), WithDialOptions(dialer))
+	defer pool.Close()
+
+	stuckCtx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stuckDone := make(chan struct{})
+
+	go func() {
+		defer close(stuckDone)
+		_, _ = pool.Conn(stuckCtx, &config.GRPCService{Name: "Stuck", Address: "passthrough:///stuck"})
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+
+	start := time.Now()
+
+	if _, err := pool.Conn(context.Background(), &config.GRPCService{Name: "Ready", Address: "passthrough:///ready"}); err != nil {
+		t.Fatalf("unexpected dial error: %v", err)
+	}
+
+	if took := time.Since(start); took > time.Second {
+		t.Fatalf("dial of a healthy service waited %s behind a stuck one", took)
+	}
+
+	cancel()
+	<-stuckDone
+}
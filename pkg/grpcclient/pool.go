@@ -0,0 +1,118 @@
+package grpcclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/invenlore/core/pkg/config"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+)
+
+// Pool keeps one connection per gRPC service and closes them all on Close.
+type Pool struct {
+	opts []Option
+
+	mu     sync.Mutex
+	conns  map[string]*grpc.ClientConn
+	order  []string
+	closed bool
+}
+
+func NewPool(opts ...Option) *Pool {
+	return &Pool{
+		opts:  opts,
+		conns: make(map[string]*grpc.ClientConn),
+	}
+}
+
+// Conn returns the pooled connection for svc, dialing it on first use.
+func (p *Pool) Conn(ctx context.Context, svc *config.GRPCService) (*grpc.ClientConn, error) {
+	if svc == nil {
+		return nil, fmt.Errorf("gRPC service is nil")
+	}
+
+	if conn, err := p.lookup(svc.Name); conn != nil || err != nil {
+		return conn, err
+	}
+
+	// Dial without holding the lock, so a slow (blocking) dial of one service
+	// does not stall callers of the others. Concurrent first calls may both
+	// dial; the loser's connection is closed.
+	conn, err := Dial(ctx, svc, p.opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		_ = conn.Close()
+
+		return nil, fmt.Errorf("gRPC client pool is closed")
+	}
+
+	if existing, ok := p.conns[svc.Name]; ok {
+		_ = conn.Close()
+
+		return existing, nil
+	}
+
+	p.conns[svc.Name] = conn
+	p.order = append(p.order, svc.Name)
+
+	return conn, nil
+}
+
+func (p *Pool) lookup(name string) (*grpc.ClientConn, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return nil, fmt.Errorf("gRPC client pool is closed")
+	}
+
+	return p.conns[name], nil
+}
+
+func (p *Pool) DialAll(ctx context.Context, services []*config.GRPCService) error {
+	for _, svc := range services {
+		if _, err := p.Conn(ctx, svc); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Close closes all connections in reverse dial order. The pool can't be reused afterwards.
+func (p *Pool) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return nil
+	}
+
+	p.closed = true
+
+	var errs []error
+
+	for i := len(p.order) - 1; i >= 0; i-- {
+		name := p.order[i]
+
+		if err := p.conns[name].Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close connection to service '%s': %w", name, err))
+		}
+
+		logrus.WithField("scope", "gRPC").Debugf("client: closed connection to service '%s'", name)
+	}
+
+	p.conns = nil
+	p.order = nil
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,198 @@
+package grpcserver
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/invenlore/core/pkg/admin"
+	"github.com/invenlore/core/pkg/config"
+	"github.com/invenlore/core/pkg/db"
+	"github.com/invenlore/core/pkg/health"
+	"github.com/invenlore/core/pkg/logger"
+	"github.com/invenlore/core/pkg/metrics"
+	"github.com/invenlore/core/pkg/recovery"
+	"github.com/invenlore/core/pkg/tracing"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	reflectionv1 "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	reflectionv1alpha "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+)
+
+// Methods that stay available while the MongoDB gate is closed.
+var gateExemptMethods = []string{
+	healthpb.Health_Check_FullMethodName,
+	healthpb.Health_Watch_FullMethodName,
+	healthpb.Health_List_FullMethodName,
+	reflectionv1.ServerReflection_ServerReflectionInfo_FullMethodName,
+	reflectionv1alpha.ServerReflection_ServerReflectionInfo_FullMethodName,
+	admin.LogLevelService_GetLogLevel_FullMethodName,
+	admin.LogLevelService_SetLogLevel_FullMethodName,
+}
+
+type Server struct {
+	*grpc.Server
+	Listener net.Listener
+	Health   healthpb.HealthServer
+}
+
+type options struct {
+	metrics            *metrics.GRPCServerMetrics
+	mongo              *db.MongoReadiness
+	mongoAllowMethods  []string
+	unaryInterceptors  []grpc.UnaryServerInterceptor
+	streamInterceptors []grpc.StreamServerInterceptor
+	serverOptions      []grpc.ServerOption
+	health             healthpb.HealthServer
+	reflection         bool
+	logLevelService    bool
+	logLevelOptions    []admin.Option
+}
+
+type Option func(*options)
+
+func WithMetrics(m *metrics.GRPCServerMetrics) Option {
+	return func(o *options) {
+		o.metrics = m
+	}
+}
+
+// WithMongoGate rejects calls with Unavailable while m is not ready.
+// Health, reflection and log level methods are always allowed.
+func WithMongoGate(m *db.MongoReadiness, allowMethods ...string) Option {
+	return func(o *options) {
+		o.mongo = m
+		o.mongoAllowMethods = append(o.mongoAllowMethods, allowMethods...)
+	}
+}
+
+// WithUnaryInterceptors appends interceptors after the core unary chain.
+func WithUnaryInterceptors(interceptors ...grpc.UnaryServerInterceptor) Option {
+	return func(o *options) {
+		o.unaryInterceptors = append(o.unaryInterceptors, interceptors...)
+	}
+}
+
+// WithStreamInterceptors appends interceptors after the core stream chain.
+func WithStreamInterceptors(interceptors ...grpc.StreamServerInterceptor) Option {
+	return func(o *options) {
+		o.streamInterceptors = append(o.streamInterceptors, interceptors...)
+	}
+}
+
+// WithServerOptions passes extra options to grpc.NewServer. Interceptor
+// options are not allowed here, use WithUnaryInterceptors/WithStreamInterceptors.
+func WithServerOptions(serverOptions ...grpc.ServerOption) Option {
+	return func(o *options) {
+		o.serverOptions = append(o.serverOptions, serverOptions...)
+	}
+}
+
+// WithHealthServer replaces the default health server, typically with a
+// health.GRPCHealthServer that has readiness sources registered.
+func WithHealthServer(h healthpb.HealthServer) Option {
+	return func(o *options) {
+		o.health = h
+	}
+}
+
+func WithoutReflection() Option {
+	return func(o *options) {
+		o.reflection = false
+	}
+}
+
+// WithLogLevelService registers admin.LogLevelService for changing log levels
+// at runtime. SetLogLevel is refused unless opts include admin.WithWrites or
+// admin.WithGRPCAuthorizer; only allow writes where the gRPC port is not
+// reachable by clients, or behind an authorizer.
+func WithLogLevelService(opts ...admin.Option) Option {
+	return func(o *options) {
+		o.logLevelService = true
+		o.logLevelOptions = opts
+	}
+}
+
+// New builds a gRPC server with the canonical interceptor chain, registers the
+// health and reflection services and listens on cfg.Host:cfg.Port.
+//
+// Unary chain, outermost first:
+//  1. logger.ServerRequestIDInterceptor: everything below sees the request ID.
+//  2. tracing.ServerTracingInterceptor: continues the caller's trace, log lines below get trace_id.
+//  3. logger.ServerLoggingInterceptor: logs the final code, including recovered panics.
+//  4. GRPCServerMetrics.UnaryServerInterceptor: counts recovered panics as Internal.
+//  5. recovery.RecoveryUnaryInterceptor: inside request ID, so the Internal status carries it.
+//  6. db.MongoGateUnary: rejected calls are still logged and counted.
+//  7. interceptors from WithUnaryInterceptors.
+//
+// The stream chain uses the stream counterparts in the same order.
+func New(cfg *config.GRPCServerConfig, opts ...Option) (*Server, error) {
+	o := &options{reflection: true}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	unary := []grpc.UnaryServerInterceptor{
+		logger.ServerRequestIDInterceptor,
+		tracing.ServerTracingInterceptor,
+		logger.ServerLoggingInterceptor,
+		o.metrics.UnaryServerInterceptor(),
+		recovery.RecoveryUnaryInterceptor,
+	}
+
+	stream := []grpc.StreamServerInterceptor{
+		logger.ServerStreamRequestIDInterceptor,
+		tracing.ServerStreamTracingInterceptor,
+		logger.ServerStreamLoggingInterceptor,
+		o.metrics.StreamServerInterceptor(),
+		recovery.RecoveryStreamInterceptor,
+	}
+
+	if o.mongo != nil {
+		allow := append(append([]string{}, gateExemptMethods...), o.mongoAllowMethods...)
+
+		unary = append(unary, db.MongoGateUnary(o.mongo, allow...))
+		stream = append(stream, db.MongoGateStream(o.mongo, allow...))
+	}
+
+	serverOptions := append([]grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(append(unary, o.unaryInterceptors...)...),
+		grpc.ChainStreamInterceptor(append(stream, o.streamInterceptors...)...),
+	}, o.serverOptions...)
+
+	var (
+		loggerEntry = logrus.WithField("scope", "gRPC")
+		listenAddr  = net.JoinHostPort(cfg.Host, cfg.Port)
+	)
+
+	loggerEntry.Info("starting gRPC server...")
+
+	ln, err := net.Listen("tcp", listenAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", listenAddr, err)
+	}
+
+	srv := grpc.NewServer(serverOptions...)
+
+	healthServer := o.health
+	if healthServer == nil {
+		healthServer = health.NewGRPCHealthServer()
+	}
+
+	healthpb.RegisterHealthServer(srv, healthServer)
+
+	if o.logLevelService {
+		admin.RegisterLogLevelService(srv, o.logLevelOptions...)
+	}
+
+	if o.reflection {
+		reflection.Register(srv)
+	}
+
+	return &Server{
+		Server:   srv,
+		Listener: ln,
+		Health:   healthServer,
+	}, nil
+}
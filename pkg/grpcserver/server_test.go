@@ -0,0 +1,60 @@
+package grpcserver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/invenlore/core/pkg/config"
+	"github.com/invenlore/core/pkg/logger"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type panickingHealth struct {
+	healthpb.UnimplementedHealthServer
+}
+
+func (panickingHealth) Check(context.Context, *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	panic("boom")
+}
+
+func TestRecoveredPanicKeepsRequestID(t *testing.T) {
+	srv, err := New(&config.GRPCServerConfig{Host: "127.0.0.1", Port: "0"}, WithHealthServer(panickingHealth{}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	go func() { _ = srv.Serve(srv.Listener) }()
+	defer srv.Stop()
+
+	conn, err := grpc.NewClient(srv.Listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("unexpected dial error: %v", err)
+	}
+	defer conn.Close()
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), logger.RequestIDMDKey, "req-1")
+
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+
+	st := status.Convert(err)
+	if st.Code() != codes.Internal {
+		t.Fatalf("expected Internal, got %v", st.Code())
+	}
+
+	var found bool
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.RequestInfo); ok && info.RequestId == "req-1" {
+			found = true
+		}
+	}
+
+	if !found {
+		t.Fatalf("expected RequestInfo detail with request id req-1")
+	}
+}
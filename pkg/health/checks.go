@@ -0,0 +1,268 @@
+package health
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/invenlore/core/pkg/config"
+	"github.com/invenlore/core/pkg/grpcclient"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	defaultReadinessCheckTimeout = 1 * time.Second
+	defaultRemoteCheckTimeout    = 2 * time.Second
+)
+
+// Check is a component health check. A failing non-critical check is reported
+// with its error in the details but never marks the whole service down.
+// A check with an Interval runs in the background and requests read its cached result.
+type Check struct {
+	Name         string
+	Check        func(ctx context.Context) error
+	Timeout      time.Duration
+	Critical     bool
+	Interval     time.Duration
+	InitialDelay time.Duration
+}
+
+func (c Check) WithTimeout(timeout time.Duration) Check {
+	c.Timeout = timeout
+	return c
+}
+
+func (c Check) WithCritical(critical bool) Check {
+	c.Critical = critical
+	return c
+}
+
+func (c Check) WithPeriodic(interval, initialDelay time.Duration) Check {
+	c.Interval = interval
+	c.InitialDelay = initialDelay
+	return c
+}
+
+func (c Check) checkerOption() health.CheckerOption {
+	if c.Interval > 0 {
+		return health.WithPeriodicCheck(c.Interval, c.InitialDelay, c.toHealthCheck())
+	}
+
+	return health.WithCheck(c.toHealthCheck())
+}
+
+func (c Check) toHealthCheck() health.Check {
+	check := health.Check{
+		Name:    c.Name,
+		Check:   c.Check,
+		Timeout: c.Timeout,
+	}
+
+	if !c.Critical {
+		check.Interceptors = []health.Interceptor{nonCriticalInterceptor()}
+	}
+
+	return check
+}
+
+func nonCriticalInterceptor() health.Interceptor {
+	return func(next health.InterceptorFunc) health.InterceptorFunc {
+		return func(ctx context.Context, name string, state health.CheckState) health.CheckState {
+			result := next(ctx, name, state)
+
+			if result.Status == health.StatusDown {
+				result.Status = health.StatusUp
+			}
+
+			return result
+		}
+	}
+}
+
+// ReadinessCheck reports the state of a readiness source such as db.MongoReadiness,
+// migrator.Manager or app.Readiness. LastError() is used as the error message when available.
+func ReadinessCheck(name string, source ReadinessSource) Check {
+	return Check{
+		Name:     name,
+		Timeout:  defaultReadinessCheckTimeout,
+		Critical: true,
+		Check: func(_ context.Context) error {
+			if source.Ready() {
+				return nil
+			}
+
+			if withErr, ok := source.(interface{ LastError() string }); ok {
+				if msg := withErr.LastError(); msg != "" {
+					return errors.New(msg)
+				}
+			}
+
+			return fmt.Errorf("%s is not ready", name)
+		},
+	}
+}
+
+func MongoCheck(m ReadinessSource) Check {
+	return ReadinessCheck("mongodb", m)
+}
+
+func MigrationsCheck(m ReadinessSource) Check {
+	return ReadinessCheck("migrations", m)
+}
+
+// GRPCServiceCheck calls grpc.health.v1 Check on a downstream connection.
+func GRPCServiceCheck(name string, conn *grpc.ClientConn) Check {
+	client := healthpb.NewHealthClient(conn)
+
+	return Check{
+		Name:    "grpc:" + name,
+		Timeout: defaultRemoteCheckTimeout,
+		Check: func(ctx context.Context) error {
+			resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{})
+			if err != nil {
+				return err
+			}
+
+			if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+				return fmt.Errorf("service status is %s", resp.GetStatus())
+			}
+
+			return nil
+		},
+	}
+}
+
+// GRPCServicesChecks builds a non-critical GRPCServiceCheck for every configured service.
+func GRPCServicesChecks(ctx context.Context, pool *grpcclient.Pool, services []*config.GRPCService) ([]Check, error) {
+	checks := make([]Check, 0, len(services))
+
+	for _, svc := range services {
+		conn, err := pool.Conn(ctx, svc)
+		if err != nil {
+			return nil, err
+		}
+
+		checks = append(checks, GRPCServiceCheck(svc.Name, conn))
+	}
+
+	return checks, nil
+}
+
+// RedisCheck sends PING (after AUTH/SELECT when configured) to the rate limiting Redis.
+func RedisCheck(cfg *config.RateLimitConfig) Check {
+	return Check{
+		Name:    "redis",
+		Timeout: defaultRemoteCheckTimeout,
+		Check: func(ctx context.Context) error {
+			return pingRedis(ctx, cfg.RedisAddress, cfg.RedisPassword, cfg.RedisDB)
+		},
+	}
+}
+
+func GoroutinesCheck(max int) Check {
+	return Check{
+		Name:    "goroutines",
+		Timeout: defaultReadinessCheckTimeout,
+		Check: func(_ context.Context) error {
+			if n := runtime.NumGoroutine(); n > max {
+				return fmt.Errorf("too many goroutines: %d > %d", n, max)
+			}
+
+			return nil
+		},
+	}
+}
+
+// DiskSpaceCheck fails when the filesystem of path has less than minFreeBytes available.
+func DiskSpaceCheck(path string, minFreeBytes uint64) Check {
+	return Check{
+		Name:    "disk:" + path,
+		Timeout: defaultReadinessCheckTimeout,
+		Check: func(_ context.Context) error {
+			free, err := diskFreeBytes(path)
+			if err != nil {
+				return err
+			}
+
+			if free < minFreeBytes {
+				return fmt.Errorf("low disk space on %s: %d bytes free, %d required", path, free, minFreeBytes)
+			}
+
+			return nil
+		},
+	}
+}
+
+func pingRedis(ctx context.Context, address, password string, db int) error {
+	var dialer net.Dialer
+
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	reader := bufio.NewReader(conn)
+
+	if password != "" {
+		if _, err := redisCommand(conn, reader, "AUTH", password); err != nil {
+			return fmt.Errorf("redis AUTH failed: %w", err)
+		}
+	}
+
+	if db != 0 {
+		if _, err := redisCommand(conn, reader, "SELECT", strconv.Itoa(db)); err != nil {
+			return fmt.Errorf("redis SELECT failed: %w", err)
+		}
+	}
+
+	reply, err := redisCommand(conn, reader, "PING")
+	if err != nil {
+		return fmt.Errorf("redis PING failed: %w", err)
+	}
+
+	if reply != "+PONG" {
+		return fmt.Errorf("unexpected redis PING reply: %q", reply)
+	}
+
+	return nil
+}
+
+func redisCommand(w io.Writer, r *bufio.Reader, args ...string) (string, error) {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return "", err
+	}
+
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+
+	line = strings.TrimRight(line, "\r\n")
+	if strings.HasPrefix(line, "-") {
+		return "", errors.New(strings.TrimPrefix(line, "-"))
+	}
+
+	return line, nil
+}
@@ -0,0 +1,190 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/invenlore/core/pkg/config"
+)
+
+func failingCheck(name string, critical bool) Check {
+	return Check{
+		Name:     name,
+		Critical: critical,
+		Check: func(context.Context) error {
+			return errors.New("down")
+		},
+	}
+}
+
+// countingCheck succeeds and counts its runs.
+func countingCheck(name string, calls *atomic.Int32) Check {
+	return Check{
+		Name:     name,
+		Critical: true,
+		Check: func(context.Context) error {
+			calls.Add(1)
+			return nil
+		},
+	}
+}
+
+func TestGetHealthHandlerCriticality(t *testing.T) {
+	cases := []struct {
+		name     string
+		checks   []Check
+		expected int
+	}{
+		{name: "no checks", expected: http.StatusOK},
+		{name: "non-critical failure", checks: []Check{failingCheck("redis", false)}, expected: http.StatusOK},
+		{name: "critical failure", checks: []Check{failingCheck("mongodb", true)}, expected: http.StatusServiceUnavailable},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := GetHealthHandler(tc.checks...)
+			t.Cleanup(handler.Stop)
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+			if rec.Code != tc.expected {
+				t.Fatalf("expected %d, got %d (%s)", tc.expected, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestStatusNotifierPublishesTransitions(t *testing.T) {
+	notifier := NewStatusNotifier()
+
+	var changes []StatusChange
+	unsubscribe := notifier.Subscribe(func(change StatusChange) {
+		changes = append(changes, change)
+	})
+	defer unsubscribe()
+
+	handler := NewHealthHandler([]Check{failingCheck("redis", false)}, WithStatusNotifier(notifier))
+	defer handler.Stop()
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	if len(changes) != 1 {
+		t.Fatalf("expected 1 status change, got %d", len(changes))
+	}
+
+	if changes[0].Component != "redis" || changes[0].Current != "down" || changes[0].Err == nil {
+		t.Fatalf("unexpected status change: %+v", changes[0])
+	}
+}
+
+func TestHandlerAppliesConfig(t *testing.T) {
+	slowCheck := func(calls *atomic.Int32) Check {
+		return Check{
+			Name:     "slow",
+			Critical: true,
+			Check: func(ctx context.Context) error {
+				calls.Add(1)
+				<-ctx.Done()
+				return ctx.Err()
+			},
+		}
+	}
+
+	periodicCheck := func(calls *atomic.Int32) Check {
+		return countingCheck("periodic", calls).WithPeriodic(10*time.Millisecond, 0)
+	}
+
+	cases := []struct {
+		name     string
+		cfg      config.HealthServerConfig
+		check    func(calls *atomic.Int32) Check
+		wait     time.Duration
+		requests int
+		expected int
+		minCalls int32
+		maxCalls int32
+	}{
+		{
+			name:     "CHECK_INTERVAL runs checks in the background",
+			cfg:      config.HealthServerConfig{CacheDuration: time.Second, CheckTimeout: time.Second, CheckInterval: 10 * time.Millisecond},
+			check:    func(calls *atomic.Int32) Check { return countingCheck("mongodb", calls) },
+			wait:     100 * time.Millisecond,
+			minCalls: 3,
+			maxCalls: 1000,
+		},
+		{
+			name:     "Check.Interval runs the check in the background",
+			cfg:      config.HealthServerConfig{CacheDuration: time.Second, CheckTimeout: time.Second},
+			check:    periodicCheck,
+			wait:     100 * time.Millisecond,
+			minCalls: 3,
+			maxCalls: 1000,
+		},
+		{
+			name:     "requests read the periodic state",
+			cfg:      config.HealthServerConfig{CacheDuration: time.Nanosecond, CheckTimeout: time.Second, CheckInterval: time.Hour},
+			check:    func(calls *atomic.Int32) Check { return countingCheck("mongodb", calls) },
+			wait:     50 * time.Millisecond,
+			requests: 5,
+			expected: http.StatusOK,
+			minCalls: 1,
+			maxCalls: 1,
+		},
+		{
+			name:     "cache duration is applied",
+			cfg:      config.HealthServerConfig{CacheDuration: time.Minute, CheckTimeout: time.Second},
+			check:    func(calls *atomic.Int32) Check { return countingCheck("mongodb", calls) },
+			requests: 3,
+			expected: http.StatusOK,
+			minCalls: 1,
+			maxCalls: 1,
+		},
+		{
+			name:     "short cache duration runs checks per request",
+			cfg:      config.HealthServerConfig{CacheDuration: time.Nanosecond, CheckTimeout: time.Second},
+			check:    func(calls *atomic.Int32) Check { return countingCheck("mongodb", calls) },
+			requests: 3,
+			expected: http.StatusOK,
+			minCalls: 3,
+			maxCalls: 3,
+		},
+		{
+			name:     "check timeout is applied",
+			cfg:      config.HealthServerConfig{CacheDuration: time.Nanosecond, CheckTimeout: 20 * time.Millisecond},
+			check:    slowCheck,
+			requests: 1,
+			expected: http.StatusServiceUnavailable,
+			minCalls: 1,
+			maxCalls: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls atomic.Int32
+
+			handler := NewHealthHandler([]Check{tc.check(&calls)}, WithConfig(&tc.cfg))
+			t.Cleanup(handler.Stop)
+
+			time.Sleep(tc.wait)
+
+			for i := 0; i < tc.requests; i++ {
+				rec := httptest.NewRecorder()
+				handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+				if rec.Code != tc.expected {
+					t.Fatalf("expected %d, got %d (%s)", tc.expected, rec.Code, rec.Body.String())
+				}
+			}
+
+			if got := calls.Load(); got < tc.minCalls || got > tc.maxCalls {
+				t.Fatalf("expected %d..%d check runs, got %d", tc.minCalls, tc.maxCalls, got)
+			}
+		})
+	}
+}
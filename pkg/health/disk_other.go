@@ -0,0 +1,9 @@
+//go:build !linux && !darwin
+
+package health
+
+import "fmt"
+
+func diskFreeBytes(path string) (uint64, error) {
+	return 0, fmt.Errorf("disk space check is not supported on this platform (%s)", path)
+}
@@ -0,0 +1,78 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+)
+
+type StatusChange struct {
+	Component string
+	Previous  health.AvailabilityStatus
+	Current   health.AvailabilityStatus
+	Err       error
+	At        time.Time
+}
+
+// StatusNotifier fans out component status transitions to subscribers.
+// Subscribers are called synchronously from the check goroutine and must not block.
+type StatusNotifier struct {
+	mu          sync.RWMutex
+	subscribers map[int]func(StatusChange)
+	nextID      int
+}
+
+func NewStatusNotifier() *StatusNotifier {
+	return &StatusNotifier{
+		subscribers: make(map[int]func(StatusChange)),
+	}
+}
+
+// Subscribe registers fn and returns a function that removes it.
+func (n *StatusNotifier) Subscribe(fn func(StatusChange)) (unsubscribe func()) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	id := n.nextID
+	n.nextID++
+	n.subscribers[id] = fn
+
+	return func() {
+		n.mu.Lock()
+		defer n.mu.Unlock()
+
+		delete(n.subscribers, id)
+	}
+}
+
+func (n *StatusNotifier) Publish(change StatusChange) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	for _, fn := range n.subscribers {
+		fn(change)
+	}
+}
+
+func (n *StatusNotifier) Interceptor() health.Interceptor {
+	return func(next health.InterceptorFunc) health.InterceptorFunc {
+		return func(ctx context.Context, name string, state health.CheckState) health.CheckState {
+			result := next(ctx, name, state)
+
+			prev, current := checkStatus(state), checkStatus(result)
+			if prev != current {
+				n.Publish(StatusChange{
+					Component: name,
+					Previous:  prev,
+					Current:   current,
+					Err:       result.Result,
+					At:        time.Now(),
+				})
+			}
+
+			return result
+		}
+	}
+}
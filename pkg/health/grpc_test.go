@@ -0,0 +1,80 @@
+package health
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+)
+
+type flagSource struct {
+	ready atomic.Bool
+}
+
+func (f *flagSource) Ready() bool {
+	return f.ready.Load()
+}
+
+type watchStream struct {
+	healthpb.Health_WatchServer
+	ctx     context.Context
+	updates chan healthpb.HealthCheckResponse_ServingStatus
+}
+
+func (w *watchStream) Context() context.Context {
+	return w.ctx
+}
+
+func (w *watchStream) Send(resp *healthpb.HealthCheckResponse) error {
+	w.updates <- resp.Status
+	return nil
+}
+
+func (w *watchStream) SetHeader(metadata.MD) error {
+	return nil
+}
+
+func TestGRPCHealthServerFollowsReadiness(t *testing.T) {
+	mongo := &flagSource{}
+
+	h := NewGRPCHealthServer()
+	h.AddService("test.v1.TestService", mongo)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream := &watchStream{ctx: ctx, updates: make(chan healthpb.HealthCheckResponse_ServingStatus, 4)}
+	go func() { _ = h.Watch(&healthpb.HealthCheckRequest{Service: "test.v1.TestService"}, stream) }()
+
+	expectStatus(t, stream.updates, healthpb.HealthCheckResponse_NOT_SERVING)
+
+	mongo.ready.Store(true)
+	h.Update()
+
+	expectStatus(t, stream.updates, healthpb.HealthCheckResponse_SERVING)
+
+	resp, err := h.Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil || resp.Status != healthpb.HealthCheckResponse_SERVING {
+		t.Fatalf("expected overall SERVING, got %v (%v)", resp, err)
+	}
+
+	h.Drain(ctx)
+
+	expectStatus(t, stream.updates, healthpb.HealthCheckResponse_NOT_SERVING)
+}
+
+func expectStatus(t *testing.T, updates <-chan healthpb.HealthCheckResponse_ServingStatus, expected healthpb.HealthCheckResponse_ServingStatus) {
+	t.Helper()
+
+	select {
+	case got := <-updates:
+		if got != expected {
+			t.Fatalf("expected %s, got %s", expected, got)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for %s", expected)
+	}
+}
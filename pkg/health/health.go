@@ -6,16 +6,59 @@ import (
 	"time"
 
 	"github.com/alexliesenfeld/health"
+	"github.com/invenlore/core/pkg/config"
+	"github.com/invenlore/core/pkg/metrics"
 	"github.com/sirupsen/logrus"
 )
 
+const (
+	defaultCacheDuration = 1 * time.Second
+	defaultCheckTimeout  = 10 * time.Second
+)
+
+type options struct {
+	metrics       *metrics.HealthMetrics
+	notifier      *StatusNotifier
+	cacheDuration time.Duration
+	timeout       time.Duration
+	interval      time.Duration
+	initialDelay  time.Duration
+}
+
+type Option func(*options)
+
+// WithConfig takes the cache duration, global check timeout and default
+// check interval from HealthServerConfig.
+func WithConfig(cfg *config.HealthServerConfig) Option {
+	return func(o *options) {
+		o.cacheDuration = cfg.CacheDuration
+		o.timeout = cfg.CheckTimeout
+		o.interval = cfg.CheckInterval
+		o.initialDelay = cfg.CheckInitialDelay
+	}
+}
+
+// WithMetrics records per-component check duration and status.
+func WithMetrics(m *metrics.HealthMetrics) Option {
+	return func(o *options) {
+		o.metrics = m
+	}
+}
+
+// WithStatusNotifier publishes component status transitions to n.
+func WithStatusNotifier(n *StatusNotifier) Option {
+	return func(o *options) {
+		o.notifier = n
+	}
+}
+
 func LoggerHealthMiddleware() health.Middleware {
 	return func(next health.MiddlewareFunc) health.MiddlewareFunc {
 		return func(r *http.Request) health.CheckerResult {
 			now := time.Now()
 			result := next(r)
 
-			logrus.WithField("scope", "health").Infof(
+			logrus.WithField("scope", "health").Debugf(
 				"processed health check request in %f seconds (result: %s)",
 				time.Since(now).Seconds(),
 				result.Status,
@@ -26,34 +69,136 @@ func LoggerHealthMiddleware() health.Middleware {
 	}
 }
 
+// LoggerHealthInterceptor logs every execution at TRACE and only status
+// transitions above that: WARN when a component goes down, INFO when it comes up.
 func LoggerHealthInterceptor() health.Interceptor {
 	return func(next health.InterceptorFunc) health.InterceptorFunc {
 		return func(ctx context.Context, name string, state health.CheckState) health.CheckState {
 			now := time.Now()
 			result := next(ctx, name, state)
 
-			logrus.WithField("scope", "health").Infof(
+			loggerEntry := logrus.WithField("scope", "health")
+
+			prev, current := checkStatus(state), checkStatus(result)
+
+			loggerEntry.Tracef(
 				"executed health check function of component %s in %f seconds (result: %s)",
 				name,
 				time.Since(now).Seconds(),
-				result.Status,
+				current,
 			)
 
+			if prev == current {
+				return result
+			}
+
+			if current == health.StatusDown {
+				loggerEntry.WithError(result.Result).Warnf("component %s is down", name)
+			} else {
+				loggerEntry.Infof("component %s is %s", name, current)
+			}
+
 			return result
 		}
 	}
 }
 
-func GetHealthHandler() http.Handler {
-	checker := health.NewChecker(
-		health.WithCacheDuration(1*time.Second),
-		health.WithTimeout(10*time.Second),
-		health.WithInterceptors(LoggerHealthInterceptor()),
-	)
+func MetricsHealthInterceptor(m *metrics.HealthMetrics) health.Interceptor {
+	return func(next health.InterceptorFunc) health.InterceptorFunc {
+		return func(ctx context.Context, name string, state health.CheckState) health.CheckState {
+			now := time.Now()
+			result := next(ctx, name, state)
+
+			m.ObserveCheck(name, string(checkStatus(result)), time.Since(now))
+
+			return result
+		}
+	}
+}
+
+// checkStatus treats any error as down, so that interceptors see failures of
+// non-critical checks, which are reported as up to the checker.
+func checkStatus(state health.CheckState) health.AvailabilityStatus {
+	if state.Result != nil {
+		return health.StatusDown
+	}
+
+	if state.Status == "" {
+		return health.StatusUnknown
+	}
+
+	return state.Status
+}
+
+// Handler serves health checks. Periodic checks (Check.Interval or
+// CHECK_INTERVAL) run in the background until Stop is called.
+type Handler struct {
+	http.Handler
+	checkers []health.Checker
+}
+
+// Stop ends the periodic checks. It is safe to call more than once.
+func (h *Handler) Stop() {
+	for _, checker := range h.checkers {
+		checker.Stop()
+	}
+}
+
+func GetHealthHandler(checks ...Check) *Handler {
+	return NewHealthHandler(checks)
+}
+
+func NewHealthHandler(checks []Check, opts ...Option) *Handler {
+	checker := newChecker(checks, opts)
+
+	return &Handler{
+		Handler:  newCheckerHandler(checker, NewResultWriter(true)),
+		checkers: []health.Checker{checker},
+	}
+}
+
+func newChecker(checks []Check, opts []Option) health.Checker {
+	o := &options{
+		cacheDuration: defaultCacheDuration,
+		timeout:       defaultCheckTimeout,
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	interceptors := []health.Interceptor{LoggerHealthInterceptor()}
+
+	if o.metrics != nil {
+		interceptors = append(interceptors, MetricsHealthInterceptor(o.metrics))
+	}
+
+	if o.notifier != nil {
+		interceptors = append(interceptors, o.notifier.Interceptor())
+	}
+
+	checkerOptions := []health.CheckerOption{
+		health.WithCacheDuration(o.cacheDuration),
+		health.WithTimeout(o.timeout),
+		health.WithInterceptors(interceptors...),
+	}
+
+	for _, check := range checks {
+		if check.Interval <= 0 && o.interval > 0 {
+			check = check.WithPeriodic(o.interval, o.initialDelay)
+		}
+
+		checkerOptions = append(checkerOptions, check.checkerOption())
+	}
+
+	return health.NewChecker(checkerOptions...)
+}
 
+func newCheckerHandler(checker health.Checker, writer health.ResultWriter, middleware ...health.Middleware) http.Handler {
 	return health.NewHandler(
 		checker,
-		health.WithMiddleware(LoggerHealthMiddleware()),
+		health.WithMiddleware(append([]health.Middleware{LoggerHealthMiddleware()}, middleware...)...),
+		health.WithResultWriter(writer),
 		health.WithStatusCodeDown(503),
 	)
 }
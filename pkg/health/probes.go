@@ -0,0 +1,129 @@
+package health
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/invenlore/core/pkg/config"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	LivenessPath  = "/livez"
+	ReadinessPath = "/readyz"
+	StartupPath   = "/startupz"
+)
+
+// Probes groups the checks of each Kubernetes probe.
+// Liveness must only contain checks that a restart can fix, never MongoDB or downstreams.
+// Startup falls back to the readiness checks when empty.
+type Probes struct {
+	Liveness  []Check
+	Readiness []Check
+	Startup   []Check
+}
+
+// NewProbesHandler mounts /livez, /readyz and /startupz with the cache, timeout
+// and interval settings of cfg.Health (see WithConfig). Responses are terse
+// (status only) unless HEALTH_VERBOSE is set or the request has ?verbose=true.
+// Startup succeeds once and then stays up; if it has not succeeded within
+// ServiceHealthTimeout the failure is logged and reported in the response info.
+// Stop the handler on shutdown to end periodic checks.
+func NewProbesHandler(cfg *config.AppConfig, probes Probes, opts ...Option) *Handler {
+	writer := NewResultWriter(cfg.Health.Verbose)
+	opts = append([]Option{WithConfig(&cfg.Health)}, opts...)
+
+	// Without startup checks /startupz shares the readiness checker, so the
+	// checks are not run (and notified or counted) twice.
+	liveness := newChecker(probes.Liveness, opts)
+	readiness := newChecker(probes.Readiness, opts)
+	checkers := []health.Checker{liveness, readiness}
+
+	startup := readiness
+	if len(probes.Startup) > 0 {
+		startup = newChecker(probes.Startup, opts)
+		checkers = append(checkers, startup)
+	}
+
+	mux := http.NewServeMux()
+
+	mux.Handle(LivenessPath, newCheckerHandler(liveness, writer))
+	mux.Handle(ReadinessPath, newCheckerHandler(readiness, writer))
+	mux.Handle(StartupPath, newCheckerHandler(startup, writer, startupMiddleware(cfg.ServiceHealthTimeout)))
+
+	return &Handler{Handler: mux, checkers: checkers}
+}
+
+type resultWriter struct {
+	verbose bool
+	json    *health.JSONResultWriter
+}
+
+// NewResultWriter writes the full check details when verbose is true or the
+// request has ?verbose=true, and only the aggregated status otherwise.
+func NewResultWriter(verbose bool) health.ResultWriter {
+	return &resultWriter{
+		verbose: verbose,
+		json:    health.NewJSONResultWriter(),
+	}
+}
+
+func (rw *resultWriter) Write(result *health.CheckerResult, statusCode int, w http.ResponseWriter, r *http.Request) error {
+	verbose := rw.verbose
+
+	if v := r.URL.Query().Get("verbose"); v != "" {
+		if parsed, err := strconv.ParseBool(v); err == nil {
+			verbose = parsed
+		}
+	}
+
+	if !verbose {
+		result = &health.CheckerResult{Status: result.Status}
+	}
+
+	return rw.json.Write(result, statusCode, w, r)
+}
+
+func startupMiddleware(timeout time.Duration) health.Middleware {
+	var (
+		started      atomic.Bool
+		deadlineOnce sync.Once
+		deadline     = time.Now().Add(timeout)
+	)
+
+	return func(next health.MiddlewareFunc) health.MiddlewareFunc {
+		return func(r *http.Request) health.CheckerResult {
+			if started.Load() {
+				return health.CheckerResult{Status: health.StatusUp}
+			}
+
+			result := next(r)
+
+			if result.Status == health.StatusUp {
+				if !started.Swap(true) {
+					logrus.WithField("scope", "health").Info("startup completed")
+				}
+
+				return result
+			}
+
+			if timeout > 0 && time.Now().After(deadline) {
+				deadlineOnce.Do(func() {
+					logrus.WithField("scope", "health").Errorf("startup did not complete within %s", timeout)
+				})
+
+				if result.Info == nil {
+					result.Info = map[string]any{}
+				}
+
+				result.Info["error"] = "startup deadline exceeded"
+			}
+
+			return result
+		}
+	}
+}
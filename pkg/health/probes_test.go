@@ -0,0 +1,105 @@
+package health
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/invenlore/core/pkg/config"
+)
+
+func TestProbesHandlerSeparatesCheckSets(t *testing.T) {
+	cfg := &config.AppConfig{ServiceHealthTimeout: time.Minute}
+
+	handler := NewProbesHandler(cfg, Probes{
+		Readiness: []Check{failingCheck("mongodb", true)},
+	})
+	t.Cleanup(handler.Stop)
+
+	serve := func(target string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+
+		return rec
+	}
+
+	if rec := serve(LivenessPath); rec.Code != http.StatusOK {
+		t.Fatalf("expected liveness to be up, got %d", rec.Code)
+	}
+
+	rec := serve(ReadinessPath)
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected readiness to be down, got %d", rec.Code)
+	}
+
+	if strings.Contains(rec.Body.String(), "mongodb") {
+		t.Fatalf("expected terse output, got %s", rec.Body.String())
+	}
+
+	if rec := serve(StartupPath + "?verbose=true"); !strings.Contains(rec.Body.String(), "mongodb") {
+		t.Fatalf("expected verbose startup output, got %s", rec.Body.String())
+	}
+}
+
+func TestProbesHandlerStartupReusesReadinessChecker(t *testing.T) {
+	var calls atomic.Int32
+
+	cfg := &config.AppConfig{
+		ServiceHealthTimeout: time.Minute,
+		Health:               config.HealthServerConfig{CacheDuration: time.Minute, CheckTimeout: time.Second},
+	}
+
+	handler := NewProbesHandler(cfg, Probes{
+		Readiness: []Check{countingCheck("mongodb", &calls)},
+	})
+	t.Cleanup(handler.Stop)
+
+	for _, target := range []string{ReadinessPath, StartupPath} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected %s to be up, got %d", target, rec.Code)
+		}
+	}
+
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("expected the readiness check to run once for both probes, got %d", got)
+	}
+}
+
+func TestProbesHandlerStopEndsPeriodicChecks(t *testing.T) {
+	var calls atomic.Int32
+
+	cfg := &config.AppConfig{
+		ServiceHealthTimeout: time.Minute,
+		Health:               config.HealthServerConfig{CacheDuration: time.Second, CheckTimeout: time.Second, CheckInterval: 10 * time.Millisecond},
+	}
+
+	handler := NewProbesHandler(cfg, Probes{
+		Liveness:  []Check{countingCheck("process", &calls)},
+		Readiness: []Check{countingCheck("mongodb", &calls)},
+		Startup:   []Check{countingCheck("migrations", &calls)},
+	})
+
+	time.Sleep(50 * time.Millisecond)
+	handler.Stop()
+	handler.Stop()
+
+	// Check functions the checker had already dispatched may still finish.
+	time.Sleep(20 * time.Millisecond)
+
+	stopped := calls.Load()
+	if stopped == 0 {
+		t.Fatalf("expected periodic checks to run before Stop")
+	}
+
+	time.Sleep(50 * time.Millisecond)
+
+	if got := calls.Load(); got != stopped {
+		t.Fatalf("expected no check runs after Stop, got %d more", got-stopped)
+	}
+}
@@ -0,0 +1,219 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const (
+	forwardedForMDKey     = "x-forwarded-for"
+	realIPMDKey           = "x-real-ip"
+	userAgentMDKey        = "user-agent"
+	gatewayUserAgentMDKey = "grpcgateway-user-agent"
+)
+
+// callerService is sent as CallerServiceMDKey by the client interceptors.
+var callerService atomic.Value // string
+
+func setCallerService(service string) {
+	if service == "unknown" {
+		service = ""
+	}
+
+	callerService.Store(service)
+}
+
+func currentCallerService() string {
+	service, _ := callerService.Load().(string)
+	return service
+}
+
+// setOutgoingCaller adds CallerServiceMDKey to md when the service name is known.
+func setOutgoingCaller(md metadata.MD) {
+	if service := currentCallerService(); service != "" {
+		md.Set(CallerServiceMDKey, service)
+	}
+}
+
+// ProxyConfig decides whose x-forwarded-for and x-real-ip metadata is used for
+// peer_ip. Forwarded values are only honoured when Trust is set and the transport
+// peer is within TrustedCIDRs; Trust without TrustedCIDRs is rejected by
+// Config.Validate and trusts nobody. Entries may be CIDRs or single addresses.
+type ProxyConfig struct {
+	Trust        bool
+	TrustedCIDRs []string
+}
+
+type proxySettings struct {
+	trust    bool
+	prefixes []netip.Prefix
+}
+
+var proxyRef atomic.Pointer[proxySettings]
+
+func (c ProxyConfig) validate() error {
+	prefixes, err := parseProxyPrefixes(c.TrustedCIDRs)
+	if err != nil {
+		return err
+	}
+
+	if c.Trust && len(prefixes) == 0 {
+		return fmt.Errorf("trusting forwarded peer addresses requires trusted proxy CIDRs")
+	}
+
+	return nil
+}
+
+func setProxyConfig(cfg ProxyConfig) {
+	prefixes, _ := parseProxyPrefixes(cfg.TrustedCIDRs)
+	proxyRef.Store(&proxySettings{trust: cfg.Trust, prefixes: prefixes})
+}
+
+// parseProxyPrefixes skips invalid entries and reports the first of them.
+func parseProxyPrefixes(values []string) ([]netip.Prefix, error) {
+	var (
+		prefixes []netip.Prefix
+		firstErr error
+	)
+
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value == "" {
+			continue
+		}
+
+		if prefix, err := netip.ParsePrefix(value); err == nil {
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+
+		addr, err := netip.ParseAddr(value)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("invalid trusted proxy '%s'", value)
+			}
+
+			continue
+		}
+
+		prefixes = append(prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+	}
+
+	return prefixes, firstErr
+}
+
+func (s *proxySettings) trusted(ip string) bool {
+	if s == nil || !s.trust {
+		return false
+	}
+
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+
+	addr = addr.Unmap()
+
+	for _, prefix := range s.prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// callerFields describes the caller of an incoming request: peer (the transport
+// address, as in serverEntry), peer_ip (see peerIP), user_agent, caller_service
+// and deadline_ms (time left when the request arrived). Missing values are omitted.
+func callerFields(ctx context.Context) logrus.Fields {
+	fields := logrus.Fields{}
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	transportIP := ""
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		addr := p.Addr.String()
+		fields["peer"] = addr
+
+		transportIP = addr
+		if host, _, err := net.SplitHostPort(addr); err == nil {
+			transportIP = host
+		}
+	}
+
+	if ip := peerIP(transportIP, md); ip != "" {
+		fields["peer_ip"] = ip
+	}
+
+	if userAgent := firstMD(md, gatewayUserAgentMDKey); userAgent != "" {
+		fields["user_agent"] = userAgent
+	} else if userAgent := firstMD(md, userAgentMDKey); userAgent != "" {
+		fields["user_agent"] = userAgent
+	}
+
+	if service := firstMD(md, CallerServiceMDKey); service != "" {
+		fields["caller_service"] = service
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		fields["deadline_ms"] = time.Until(deadline).Milliseconds()
+	}
+
+	return fields
+}
+
+// peerIP is the transport peer unless it is a trusted proxy (see ProxyConfig).
+// Then x-forwarded-for is walked from the right, skipping trusted proxies, so
+// entries a client prepended are never picked over the address the proxy saw;
+// x-real-ip is used when there is no x-forwarded-for.
+func peerIP(transportIP string, md metadata.MD) string {
+	proxy := proxyRef.Load()
+	if !proxy.trusted(transportIP) {
+		return transportIP
+	}
+
+	if forwarded := md.Get(forwardedForMDKey); len(forwarded) > 0 {
+		hops := strings.Split(strings.Join(forwarded, ","), ",")
+
+		ip := ""
+
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop := strings.TrimSpace(hops[i])
+			if hop == "" {
+				continue
+			}
+
+			ip = hop
+			if !proxy.trusted(hop) {
+				break
+			}
+		}
+
+		if ip != "" {
+			return ip
+		}
+	}
+
+	if ip := firstMD(md, realIPMDKey); ip != "" {
+		return ip
+	}
+
+	return transportIP
+}
+
+func firstMD(md metadata.MD, key string) string {
+	if vals := md.Get(key); len(vals) > 0 {
+		return strings.TrimSpace(vals[0])
+	}
+
+	return ""
+}
@@ -0,0 +1,136 @@
+package logger
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+)
+
+// serverCallerLine makes a Health/Check call over loopback TCP with the given
+// outgoing metadata and returns the server completion line.
+func serverCallerLine(t *testing.T, proxy ProxyConfig, md ...string) map[string]any {
+	t.Helper()
+
+	std := logrus.StandardLogger()
+	prevOut, prevFormatter, prevLevel := std.Out, std.Formatter, std.GetLevel()
+	prevCaller := currentCallerService()
+
+	t.Cleanup(func() {
+		std.SetOutput(prevOut)
+		std.SetFormatter(prevFormatter)
+		std.SetLevel(prevLevel)
+		setCallerService(prevCaller)
+		setProxyConfig(ProxyConfig{})
+	})
+
+	var out syncBuffer
+
+	std.SetOutput(&out)
+	std.SetFormatter(jsonFormatter())
+	std.SetLevel(logrus.TraceLevel)
+	setCallerService("wiki")
+	setProxyConfig(proxy)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected listen error: %v", err)
+	}
+
+	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(ServerRequestIDInterceptor, ServerLoggingInterceptor))
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient(
+		lis.Addr().String(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUserAgent("wiki-client"),
+		grpc.WithUnaryInterceptor(ClientRequestIDInterceptor),
+	)
+	if err != nil {
+		t.Fatalf("unexpected dial error: %v", err)
+	}
+
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	ctx = metadata.AppendToOutgoingContext(ctx, md...)
+
+	if _, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{}); err != nil {
+		t.Fatalf("unexpected call error: %v", err)
+	}
+
+	for _, l := range out.lines(t) {
+		if l["message"] == "server: gRPC request completed successfully" {
+			return l
+		}
+	}
+
+	t.Fatalf("expected a server completion line")
+
+	return nil
+}
+
+func TestServerLogsCallerFields(t *testing.T) {
+	line := serverCallerLine(t,
+		ProxyConfig{Trust: true, TrustedCIDRs: []string{"127.0.0.1", "10.0.0.0/8"}},
+		"x-forwarded-for", "198.51.100.9, 203.0.113.7, 10.0.0.1",
+	)
+
+	// 10.0.0.1 is a trusted hop, 198.51.100.9 was prepended by the client.
+	if line["peer_ip"] != "203.0.113.7" || line["caller_service"] != "wiki" {
+		t.Fatalf("unexpected caller fields: %v", line)
+	}
+
+	if addr, _ := line["peer"].(string); !strings.HasPrefix(addr, "127.0.0.1:") {
+		t.Fatalf("expected the transport address in peer, got %v", line["peer"])
+	}
+
+	if _, ok := line["peer_addr"]; ok {
+		t.Fatalf("expected the transport address to be logged once, got peer_addr %v", line["peer_addr"])
+	}
+
+	if userAgent, _ := line["user_agent"].(string); !strings.HasPrefix(userAgent, "wiki-client") {
+		t.Fatalf("unexpected user_agent: %v", line["user_agent"])
+	}
+
+	if deadline, _ := line["deadline_ms"].(float64); deadline <= 0 || deadline > 60000 {
+		t.Fatalf("unexpected deadline_ms: %v", line["deadline_ms"])
+	}
+}
+
+func TestServerIgnoresForwardedHeadersFromUntrustedPeers(t *testing.T) {
+	cases := []struct {
+		name  string
+		proxy ProxyConfig
+	}{
+		{name: "proxy not trusted"},
+		{name: "peer outside trusted CIDRs", proxy: ProxyConfig{Trust: true, TrustedCIDRs: []string{"10.0.0.0/8"}}},
+		{name: "trust without CIDRs", proxy: ProxyConfig{Trust: true}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			line := serverCallerLine(t, tc.proxy,
+				"x-forwarded-for", "203.0.113.7",
+				"x-real-ip", "203.0.113.8",
+			)
+
+			if line["peer_ip"] != "127.0.0.1" {
+				t.Fatalf("expected the forged headers to be ignored, got peer_ip %v", line["peer_ip"])
+			}
+		})
+	}
+}
@@ -1,6 +1,12 @@
 package logger
 
 const (
+	// Deprecated: RequestIDCtxKey is a plain string context key that can collide
+	// with other packages. Use WithRequestID and RequestIDFromContext instead;
+	// values stored under this key are still read during the migration period.
 	RequestIDCtxKey = "requestID"
 	RequestIDMDKey  = "x-request-id"
+
+	// CallerServiceMDKey carries the SERVICE_NAME of the calling service.
+	CallerServiceMDKey = "x-caller-service"
 )
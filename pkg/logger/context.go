@@ -0,0 +1,64 @@
+package logger
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/peer"
+)
+
+type entryCtxKey struct{}
+
+// WithContext stores entry in ctx, FromContext(ctx) returns it afterwards.
+func WithContext(ctx context.Context, entry *logrus.Entry) context.Context {
+	return context.WithValue(ctx, entryCtxKey{}, entry)
+}
+
+// FromContext returns the entry stored in ctx by WithContext or the server
+// interceptors (request_id, rpc_method, peer and user_id when set). Without one,
+// it returns an entry of the standard logger with the request ID if ctx has it.
+// The result is never nil and carries ctx.
+func FromContext(ctx context.Context) *logrus.Entry {
+	if ctx == nil {
+		return logrus.NewEntry(logrus.StandardLogger())
+	}
+
+	if entry, ok := ctx.Value(entryCtxKey{}).(*logrus.Entry); ok && entry != nil {
+		return entry.WithContext(ctx)
+	}
+
+	entry := logrus.NewEntry(logrus.StandardLogger())
+
+	if requestID := RequestIDFromContext(ctx); requestID != "" {
+		entry = entry.WithField("request_id", requestID)
+	}
+
+	return entry.WithContext(ctx)
+}
+
+// WithFields adds fields to the entry carried by ctx.
+func WithFields(ctx context.Context, fields logrus.Fields) context.Context {
+	return WithContext(ctx, FromContext(ctx).WithFields(fields))
+}
+
+// WithUserID is meant for auth interceptors, so that later log lines carry the authenticated user.
+func WithUserID(ctx context.Context, userID string) context.Context {
+	if userID == "" {
+		return ctx
+	}
+
+	return WithFields(ctx, logrus.Fields{"user_id": userID})
+}
+
+func serverEntry(ctx context.Context, requestID, fullMethod string) *logrus.Entry {
+	fields := logrus.Fields{
+		"request_id": requestID,
+		"rpc_method": fullMethod,
+	}
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		fields["peer"] = p.Addr.String()
+	}
+
+	return FromContext(ctx).WithFields(fields)
+}
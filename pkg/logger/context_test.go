@@ -0,0 +1,68 @@
+package logger
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestServerRequestIDInterceptorEnrichesContextLogger(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDMDKey, "req-1"))
+	ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 5000}})
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.v1.TestService/Get"}
+
+	var entry *logrus.Entry
+
+	_, err := ServerRequestIDInterceptor(ctx, nil, info, func(ctx context.Context, _ any) (any, error) {
+		entry = FromContext(WithUserID(ctx, "user-1"))
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := logrus.Fields{
+		"request_id": "req-1",
+		"rpc_method": "/test.v1.TestService/Get",
+		"peer":       "10.0.0.1:5000",
+		"user_id":    "user-1",
+	}
+
+	for key, value := range expected {
+		if entry.Data[key] != value {
+			t.Fatalf("expected %s=%v, got %v", key, value, entry.Data[key])
+		}
+	}
+}
+
+func TestFromContextWithoutEntry(t *testing.T) {
+	entry := FromContext(context.Background())
+	if entry == nil || len(entry.Data) != 0 {
+		t.Fatalf("expected empty entry, got %v", entry)
+	}
+}
+
+func TestTraceHookAddsSpanIDs(t *testing.T) {
+	spanCtx := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{2},
+		TraceFlags: trace.FlagsSampled,
+	})
+
+	entry := logrus.WithContext(trace.ContextWithSpanContext(context.Background(), spanCtx))
+
+	if err := (traceHook{}).Fire(entry); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if entry.Data["trace_id"] != spanCtx.TraceID().String() || entry.Data["span_id"] != spanCtx.SpanID().String() {
+		t.Fatalf("unexpected trace fields: %v", entry.Data)
+	}
+}
@@ -2,7 +2,9 @@ package logger
 
 import (
 	"context"
+	"errors"
 	"io"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,11 +16,15 @@ import (
 
 type wrappedClientStreamLogger struct {
 	grpc.ClientStream
-	ctx       context.Context
-	reqID     string
-	method    string
-	target    string
-	startTime time.Time
+	ctx           context.Context
+	reqID         string
+	method        string
+	target        string
+	startTime     time.Time
+	serverStreams bool
+	stats         streamStats
+	once          sync.Once
+	stopCancel    func() bool
 }
 
 func (w *wrappedClientStreamLogger) Context() context.Context {
@@ -28,23 +34,16 @@ func (w *wrappedClientStreamLogger) Context() context.Context {
 func (w *wrappedClientStreamLogger) SendMsg(m any) error {
 	err := w.ClientStream.SendMsg(m)
 
-	if err != nil {
-		duration := time.Since(w.startTime)
-		statusCode := status.Code(err)
-
-		logrus.WithFields(logrus.Fields{
-			"scope":      "gRPC",
-			"request_id": w.reqID,
-			"latency_ms": duration.Milliseconds(),
-			"rpc_method": w.method,
-			"target":     w.target,
-			"grpc_code":  statusCode,
-			"error":      err.Error(),
-		}).Errorf("client: failed to send message in stream")
-	} else {
+	switch {
+	case errors.Is(err, io.EOF):
+		// The stream is broken; the status is returned by the next RecvMsg.
+	case err != nil:
+		w.end(err)
+	default:
 		logrus.WithFields(logrus.Fields{
 			"scope":      "gRPC",
 			"request_id": w.reqID,
+			"bytes":      w.stats.sent(m),
 		}).Tracef("client: sent message in stream %s", w.method)
 	}
 
@@ -54,46 +53,69 @@ func (w *wrappedClientStreamLogger) SendMsg(m any) error {
 func (w *wrappedClientStreamLogger) RecvMsg(m any) error {
 	err := w.ClientStream.RecvMsg(m)
 
-	if err != nil {
-		if err != io.EOF {
-			duration := time.Since(w.startTime)
-			statusCode := status.Code(err)
-
-			logrus.WithFields(logrus.Fields{
-				"scope":      "gRPC",
-				"request_id": w.reqID,
-				"latency_ms": duration.Milliseconds(),
-				"rpc_method": w.method,
-				"target":     w.target,
-				"grpc_code":  statusCode,
-				"error":      err.Error(),
-			}).Errorf("client: failed to receive message in stream")
-		}
-	} else {
+	switch {
+	case errors.Is(err, io.EOF):
+		w.end(nil)
+	case err != nil:
+		w.end(err)
+	default:
 		logrus.WithFields(logrus.Fields{
 			"scope":      "gRPC",
 			"request_id": w.reqID,
+			"bytes":      w.stats.received(m),
 		}).Tracef("client: received message in stream %s", w.method)
+
+		if !w.serverStreams {
+			w.end(nil)
+		}
 	}
 
 	return err
 }
 
-func ClientRequestIDInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
-	requestID, _ := ctx.Value(RequestIDCtxKey).(string)
-	if requestID == "" {
-		if md, ok := metadata.FromOutgoingContext(ctx); ok {
-			if vals := md.Get(RequestIDMDKey); len(vals) > 0 && vals[0] != "" {
-				requestID = vals[0]
-			}
+// end is called from the stream methods, which only run after stopCancel is set.
+func (w *wrappedClientStreamLogger) end(err error) {
+	w.stopCancel()
+	w.finish(err)
+}
+
+// finish logs the completion line once: on the first RecvMsg error (io.EOF
+// counts as success), after the only response of a client-streaming call, on
+// a SendMsg error or when the stream context is cancelled first.
+func (w *wrappedClientStreamLogger) finish(err error) {
+	w.once.Do(func() {
+		statusCode := status.Code(err)
+
+		logFields := logrus.Fields{
+			"scope":      "gRPC",
+			"request_id": w.reqID,
+			"latency_ms": time.Since(w.startTime).Milliseconds(),
+			"rpc_method": w.method,
+			"target":     w.target,
+			"grpc_code":  statusCode,
 		}
-	}
+
+		w.stats.addFields(logFields)
+
+		loggerEntry := logrus.WithContext(w.ctx)
+
+		if err != nil {
+			logFields["error"] = err.Error()
+			loggerEntry.WithFields(logFields).Errorf("client: gRPC stream failed")
+		} else {
+			loggerEntry.WithFields(logFields).Tracef("client: gRPC stream completed successfully")
+		}
+	})
+}
+
+func ClientRequestIDInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	requestID := outgoingRequestID(ctx)
 
 	if requestID == "" {
 		requestID = uuid.NewString()
 	}
 
-	ctx = context.WithValue(ctx, RequestIDCtxKey, requestID)
+	ctx = WithRequestID(ctx, requestID)
 
 	md, ok := metadata.FromOutgoingContext(ctx)
 	if !ok {
@@ -103,6 +125,7 @@ func ClientRequestIDInterceptor(ctx context.Context, method string, req, reply a
 	}
 
 	md.Set(RequestIDMDKey, requestID)
+	setOutgoingCaller(md)
 	newCtx := metadata.NewOutgoingContext(ctx, md)
 
 	logrus.WithFields(logrus.Fields{
@@ -118,22 +141,25 @@ func ClientRequestIDInterceptor(ctx context.Context, method string, req, reply a
 }
 
 func ClientLoggingInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
-	requestID, _ := ctx.Value(RequestIDCtxKey).(string)
-	if requestID == "" {
-		if md, ok := metadata.FromOutgoingContext(ctx); ok {
-			if vals := md.Get(RequestIDMDKey); len(vals) > 0 && vals[0] != "" {
-				requestID = vals[0]
-			}
-		}
-	}
+	requestID := outgoingRequestID(ctx)
 
 	if requestID == "" {
 		requestID = "no-request-id"
 	}
 
+	loggerEntry := logrus.WithContext(ctx)
+	payloadEntry := loggerEntry.WithFields(logrus.Fields{
+		"scope":      "gRPC",
+		"request_id": requestID,
+		"rpc_method": method,
+		"target":     cc.Target(),
+	})
+
+	logPayload(payloadEntry, req, "client: gRPC request payload")
+
 	startTime := time.Now()
 
-	logrus.WithFields(logrus.Fields{
+	loggerEntry.WithFields(logrus.Fields{
 		"scope":      "gRPC",
 		"request_id": requestID,
 	}).Tracef(
@@ -156,36 +182,41 @@ func ClientLoggingInterceptor(ctx context.Context, method string, req, reply any
 		"target":     cc.Target(),
 	}
 
+	checkSlowCall(loggerEntry, logFields, SlowCall{
+		Side:    "client",
+		Method:  method,
+		Target:  cc.Target(),
+		Code:    statusCode,
+		Latency: duration,
+	})
+
 	if err != nil {
 		logFields["error"] = err.Error()
-		logrus.WithFields(logFields).Errorf("client: gRPC request failed")
+		loggerEntry.WithFields(logFields).Errorf("client: gRPC request failed")
 	} else {
-		logrus.WithFields(logFields).Tracef("client: gRPC request completed successfully")
+		loggerEntry.WithFields(logFields).Tracef("client: gRPC request completed successfully")
+		logPayload(payloadEntry, reply, "client: gRPC response payload")
 	}
 
 	return err
 }
 
+// ClientStreamInterceptor is ClientStreamRequestIDInterceptor followed by
+// ClientStreamLoggingInterceptor.
 func ClientStreamInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
-	requestID, _ := ctx.Value(RequestIDCtxKey).(string)
-	if requestID == "" {
-		if md, ok := metadata.FromOutgoingContext(ctx); ok {
-			if vals := md.Get(RequestIDMDKey); len(vals) > 0 && vals[0] != "" {
-				requestID = vals[0]
-			}
-		}
-	}
+	return ClientStreamRequestIDInterceptor(ctx, desc, cc, method, func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return ClientStreamLoggingInterceptor(ctx, desc, cc, method, streamer, opts...)
+	}, opts...)
+}
+
+func ClientStreamRequestIDInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	requestID := outgoingRequestID(ctx)
 
 	if requestID == "" {
 		requestID = uuid.NewString()
 	}
 
-	ctx = context.WithValue(ctx, RequestIDCtxKey, requestID)
-
-	logFields := logrus.Fields{
-		"scope":      "gRPC",
-		"request_id": requestID,
-	}
+	ctx = WithRequestID(ctx, requestID)
 
 	md, ok := metadata.FromOutgoingContext(ctx)
 	if !ok {
@@ -195,20 +226,46 @@ func ClientStreamInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grp
 	}
 
 	md.Set(RequestIDMDKey, requestID)
+	setOutgoingCaller(md)
 	newCtx := metadata.NewOutgoingContext(ctx, md)
 
+	logrus.WithFields(logrus.Fields{
+		"scope":      "gRPC",
+		"request_id": requestID,
+	}).Tracef(
+		"client: outgoing stream to %s, method: %s",
+		cc.Target(),
+		method,
+	)
+
+	return streamer(newCtx, desc, cc, method, opts...)
+}
+
+func ClientStreamLoggingInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	requestID := outgoingRequestID(ctx)
+
+	if requestID == "" {
+		requestID = "no-request-id"
+	}
+
+	logFields := logrus.Fields{
+		"scope":      "gRPC",
+		"request_id": requestID,
+	}
+
+	loggerEntry := logrus.WithContext(ctx)
 	startTime := time.Now()
-	logrus.WithFields(logFields).Tracef("client: initiating stream: %s (target: %s)", method, cc.Target())
+	loggerEntry.WithFields(logFields).Tracef("client: initiating stream: %s (target: %s)", method, cc.Target())
 
-	actualClientStream, err := streamer(newCtx, desc, cc, method, opts...)
+	actualClientStream, err := streamer(ctx, desc, cc, method, opts...)
 	if err != nil {
-		logrus.WithFields(logFields).Errorf("client: failed to call streamer function: %v", err)
+		loggerEntry.WithFields(logFields).Errorf("client: failed to call streamer function: %v", err)
 
 		return nil, err
 	}
 
 	if actualClientStream == nil {
-		logrus.WithFields(logFields).Errorf("client: streamer function returned nil ClientStream")
+		loggerEntry.WithFields(logFields).Errorf("client: streamer function returned nil ClientStream")
 
 		return nil, err
 	}
@@ -218,13 +275,18 @@ func ClientStreamInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grp
 	}
 
 	wrapped := &wrappedClientStreamLogger{
-		ClientStream: actualClientStream,
-		ctx:          newCtx,
-		reqID:        requestID,
-		method:       method,
-		target:       cc.Target(),
-		startTime:    startTime,
+		ClientStream:  actualClientStream,
+		ctx:           ctx,
+		reqID:         requestID,
+		method:        method,
+		target:        cc.Target(),
+		startTime:     startTime,
+		serverStreams: desc.ServerStreams,
 	}
 
+	wrapped.stopCancel = context.AfterFunc(ctx, func() {
+		wrapped.finish(status.FromContextError(ctx.Err()).Err())
+	})
+
 	return wrapped, nil
 }
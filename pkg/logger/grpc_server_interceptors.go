@@ -2,6 +2,8 @@ package logger
 
 import (
 	"context"
+	"errors"
+	"io"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,6 +17,7 @@ type wrappedServerStreamLogger struct {
 	stream      grpc.ServerStream
 	reqID       string
 	modifiedCtx context.Context
+	stats       *streamStats
 }
 
 func (w *wrappedServerStreamLogger) SendMsg(m any) error {
@@ -33,6 +36,7 @@ func (w *wrappedServerStreamLogger) SendMsg(m any) error {
 		logrus.WithFields(logrus.Fields{
 			"scope":      "gRPC",
 			"request_id": w.reqID,
+			"bytes":      w.stats.sent(m),
 		}).Trace("server: sent message in gRPC stream")
 	}
 
@@ -42,7 +46,12 @@ func (w *wrappedServerStreamLogger) SendMsg(m any) error {
 func (w *wrappedServerStreamLogger) RecvMsg(m any) error {
 	err := w.stream.RecvMsg(m)
 
-	if err != nil {
+	if errors.Is(err, io.EOF) {
+		logrus.WithFields(logrus.Fields{
+			"scope":      "gRPC",
+			"request_id": w.reqID,
+		}).Trace("server: client closed sending side of gRPC stream")
+	} else if err != nil {
 		statusCode := status.Code(err)
 
 		logrus.WithFields(logrus.Fields{
@@ -55,6 +64,7 @@ func (w *wrappedServerStreamLogger) RecvMsg(m any) error {
 		logrus.WithFields(logrus.Fields{
 			"scope":      "gRPC",
 			"request_id": w.reqID,
+			"bytes":      w.stats.received(m),
 		}).Trace("server: received message in gRPC stream")
 	}
 
@@ -90,27 +100,32 @@ func ServerRequestIDInterceptor(ctx context.Context, req any, info *grpc.UnarySe
 		requestID = uuid.NewString()
 	}
 
-	newCtx := context.WithValue(ctx, RequestIDCtxKey, requestID)
+	newCtx := WithRequestID(ctx, requestID)
+	newCtx = WithContext(newCtx, serverEntry(newCtx, requestID, info.FullMethod))
+
 	return handler(newCtx, req)
 }
 
 func ServerLoggingInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
-	reqID, _ := ctx.Value(RequestIDCtxKey).(string)
-	if reqID == "" {
-		if md, ok := metadata.FromIncomingContext(ctx); ok {
-			if vals := md.Get(RequestIDMDKey); len(vals) > 0 && vals[0] != "" {
-				reqID = vals[0]
-			}
-		}
-	}
+	reqID := incomingRequestID(ctx)
 
 	if reqID == "" {
 		reqID = "no-request-id"
 	}
 
+	loggerEntry := logrus.WithContext(ctx)
+	payloadEntry := loggerEntry.WithFields(logrus.Fields{
+		"scope":      "gRPC",
+		"request_id": reqID,
+		"rpc_method": info.FullMethod,
+	})
+
+	logPayload(payloadEntry, req, "server: gRPC request payload")
+
+	caller := callerFields(ctx)
 	startTime := time.Now()
 
-	logrus.WithFields(logrus.Fields{
+	loggerEntry.WithFields(logrus.Fields{
 		"scope":      "gRPC",
 		"request_id": reqID,
 	}).Tracef("server: received gRPC request: %s", info.FullMethod)
@@ -128,11 +143,23 @@ func ServerLoggingInterceptor(ctx context.Context, req any, info *grpc.UnaryServ
 		"grpc_code":  statusCode,
 	}
 
+	for key, value := range caller {
+		logFields[key] = value
+	}
+
+	checkSlowCall(loggerEntry, logFields, SlowCall{
+		Side:    "server",
+		Method:  info.FullMethod,
+		Code:    statusCode,
+		Latency: duration,
+	})
+
 	if err != nil {
 		logFields["error"] = err.Error()
-		logrus.WithFields(logFields).Errorf("server: gRPC request failed")
+		loggerEntry.WithFields(logFields).Errorf("server: gRPC request failed")
 	} else {
-		logrus.WithFields(logFields).Trace("server: gRPC request completed successfully")
+		loggerEntry.WithFields(logFields).Trace("server: gRPC request completed successfully")
+		logPayload(payloadEntry, resp, "server: gRPC response payload")
 	}
 
 	return resp, err
@@ -152,12 +179,17 @@ func ServerStreamRequestIDInterceptor(srv any, ss grpc.ServerStream, info *grpc.
 		requestID = uuid.NewString()
 	}
 
-	newCtx := context.WithValue(ctx, RequestIDCtxKey, requestID)
+	stats := &streamStats{}
+
+	newCtx := WithRequestID(ctx, requestID)
+	newCtx = WithContext(newCtx, serverEntry(newCtx, requestID, info.FullMethod))
+	newCtx = withStreamStats(newCtx, stats)
 
 	wrappedStreamWithNewCtx := &wrappedServerStreamLogger{
 		stream:      ss,
 		reqID:       requestID,
 		modifiedCtx: newCtx,
+		stats:       stats,
 	}
 
 	return handler(srv, wrappedStreamWithNewCtx)
@@ -168,19 +200,28 @@ func ServerStreamLoggingInterceptor(srv any, ss grpc.ServerStream, info *grpc.St
 
 	if ws, ok := ss.(*wrappedServerStreamLogger); ok {
 		reqIDStr = ws.reqID
-	} else {
-		if v, ok := ss.Context().Value(RequestIDCtxKey).(string); ok && v != "" {
-			reqIDStr = v
-		} else if md, ok := metadata.FromIncomingContext(ss.Context()); ok {
-			if vals := md.Get(RequestIDMDKey); len(vals) > 0 && vals[0] != "" {
-				reqIDStr = vals[0]
-			}
+	} else if v := incomingRequestID(ss.Context()); v != "" {
+		reqIDStr = v
+	}
+
+	// Counters come from the stream wrapped by ServerStreamRequestIDInterceptor,
+	// which may be hidden behind other wrappers such as the tracing one.
+	stats := streamStatsFromContext(ss.Context())
+	if stats == nil {
+		stats = &streamStats{}
+		ss = &wrappedServerStreamLogger{
+			stream:      ss,
+			reqID:       reqIDStr,
+			modifiedCtx: withStreamStats(ss.Context(), stats),
+			stats:       stats,
 		}
 	}
 
+	loggerEntry := logrus.WithContext(ss.Context())
+	caller := callerFields(ss.Context())
 	startTime := time.Now()
 
-	logrus.WithFields(logrus.Fields{
+	loggerEntry.WithFields(logrus.Fields{
 		"scope":      "gRPC",
 		"request_id": reqIDStr,
 	}).Tracef("server: received gRPC stream: %s", info.FullMethod)
@@ -198,11 +239,17 @@ func ServerStreamLoggingInterceptor(srv any, ss grpc.ServerStream, info *grpc.St
 		"grpc_code":  statusCode,
 	}
 
+	for key, value := range caller {
+		logFields[key] = value
+	}
+
+	stats.addFields(logFields)
+
 	if err != nil {
 		logFields["error"] = err.Error()
-		logrus.WithFields(logFields).Errorf("server: gRPC stream failed")
+		loggerEntry.WithFields(logFields).Errorf("server: gRPC stream failed")
 	} else {
-		logrus.WithFields(logFields).Trace("server: gRPC stream completed successfully")
+		loggerEntry.WithFields(logFields).Trace("server: gRPC stream completed successfully")
 	}
 
 	return err
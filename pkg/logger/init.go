@@ -1,21 +1,32 @@
 package logger
 
 import (
-	"fmt"
+	"log/slog"
 	"os"
-	"path/filepath"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/invenlore/core/pkg/serviceinfo"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// Config.ScopeLevels overrides Level for entries with a matching "scope" field
+// (case-insensitive), e.g. {"gRPC": TRACE, "health": WARN}.
 type Config struct {
-	Level   LogLevel
-	Env     string
-	Service string
-	Version string
+	Level       LogLevel
+	ScopeLevels ScopeLevels
+	Env         string
+	Service     string
+	Version     string
+	Format      Format
+	Output      []Output
+	File        FileConfig
+	Payload     PayloadConfig
+	Sampling    SamplingConfig
+	SlowCall    SlowCallConfig
+	Proxy       ProxyConfig
 }
 
 type defaultFieldsHook struct {
@@ -36,6 +47,30 @@ func (h defaultFieldsHook) Fire(entry *logrus.Entry) error {
 	return nil
 }
 
+// traceHook adds trace_id and span_id for entries created with WithContext
+// (or FromContext) inside a recorded span.
+type traceHook struct{}
+
+func (traceHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (traceHook) Fire(entry *logrus.Entry) error {
+	if entry.Context == nil {
+		return nil
+	}
+
+	spanCtx := trace.SpanContextFromContext(entry.Context)
+	if !spanCtx.IsValid() {
+		return nil
+	}
+
+	entry.Data["trace_id"] = spanCtx.TraceID().String()
+	entry.Data["span_id"] = spanCtx.SpanID().String()
+
+	return nil
+}
+
 var (
 	initOnce       sync.Once
 	defaultHookRef *defaultFieldsHook
@@ -43,8 +78,25 @@ var (
 
 func Init(cfg Config) {
 	logger := logrus.StandardLogger()
-	logger.SetFormatter(jsonFormatter())
-	logger.SetLevel(cfg.Level.ToLogrusLevel())
+
+	configErr := cfg.Validate()
+	if configErr != nil {
+		cfg.Format = FormatJSON
+		cfg.Output = nil
+	}
+
+	var sampling *samplingFormatter
+
+	formatter := newFormatter(cfg.Format)
+	if cfg.Sampling.Enabled {
+		sampling = newSamplingFormatter(formatter, cfg.Sampling)
+		formatter = sampling
+	}
+
+	logger.SetFormatter(&levelFormatter{next: formatter})
+	setSamplingFormatter(sampling)
+	setOutput(logger, cfg.Output, cfg.File)
+	setConfiguredLevels(cfg.Level, cfg.ScopeLevels)
 
 	fields := logrus.Fields{
 		"service": cfg.Service,
@@ -55,11 +107,24 @@ func Init(cfg Config) {
 	initOnce.Do(func() {
 		defaultHookRef = &defaultFieldsHook{fields: fields}
 		logger.AddHook(defaultHookRef)
+		logger.AddHook(traceHook{})
 	})
 
 	if defaultHookRef != nil {
 		defaultHookRef.fields = fields
 	}
+
+	setPayloadConfig(cfg.Payload)
+	setCallerService(cfg.Service)
+	setSlowCallConfig(cfg.SlowCall)
+	setProxyConfig(cfg.Proxy)
+
+	// Route log/slog (and with it the standard log package) through logrus.
+	slog.SetDefault(slog.New(NewSlogHandler(logger)))
+
+	if configErr != nil {
+		logrus.WithField("scope", "logger").WithError(configErr).Warn("invalid log config, falling back to json on stdout")
+	}
 }
 
 func InitEarlyFromEnv() {
@@ -71,23 +136,14 @@ func InitEarlyFromEnv() {
 		cfg.Level = LogLevelInfo
 	}
 
-	cfg.Env = strings.TrimSpace(os.Getenv("APP_ENV"))
-	cfg.Service = strings.TrimSpace(os.Getenv("SERVICE_NAME"))
-	cfg.Version = strings.TrimSpace(os.Getenv("SERVICE_VERSION"))
+	_ = cfg.ScopeLevels.UnmarshalText([]byte(os.Getenv("APP_LOG_LEVELS")))
+	_ = cfg.Format.UnmarshalText([]byte(os.Getenv("APP_LOG_FORMAT")))
 
-	if cfg.Version == "" {
-		if version, err := readServiceVersion(); err == nil {
-			cfg.Version = version
-		}
-	}
+	info := serviceinfo.FromEnv()
 
-	if cfg.Service == "" {
-		cfg.Service = "unknown"
-	}
-
-	if cfg.Env == "" {
-		cfg.Env = "dev"
-	}
+	cfg.Env = info.Env
+	cfg.Service = info.Name
+	cfg.Version = info.Version
 
 	Init(cfg)
 }
@@ -95,32 +151,14 @@ func InitEarlyFromEnv() {
 func jsonFormatter() *logrus.JSONFormatter {
 	return &logrus.JSONFormatter{
 		TimestampFormat: time.RFC3339Nano,
-		FieldMap: logrus.FieldMap{
-			logrus.FieldKeyTime:  "timestamp",
-			logrus.FieldKeyLevel: "level",
-			logrus.FieldKeyMsg:   "message",
-		},
+		FieldMap:        defaultFieldMap(),
 	}
 }
 
-func readServiceVersion() (string, error) {
-	const maxVersionLength = 128
-
-	path := filepath.FromSlash("/app/version.txt")
-
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return "", err
-	}
-
-	version := strings.TrimSpace(string(data))
-	if version == "" {
-		return "", fmt.Errorf("service version file is empty")
-	}
-
-	if len(version) > maxVersionLength {
-		return "", fmt.Errorf("service version length exceeds limit")
+func defaultFieldMap() logrus.FieldMap {
+	return logrus.FieldMap{
+		logrus.FieldKeyTime:  "timestamp",
+		logrus.FieldKeyLevel: "level",
+		logrus.FieldKeyMsg:   "message",
 	}
-
-	return version, nil
 }
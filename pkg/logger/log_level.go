@@ -29,6 +29,15 @@ func (s *LogLevel) UnmarshalText(text []byte) error {
 	return nil
 }
 
+func (s LogLevel) valid() bool {
+	switch s {
+	case LogLevelInfo, LogLevelDebug, LogLevelTrace, LogLevelError, LogLevelWarn, LogLevelFatal, LogLevelPanic:
+		return true
+	default:
+		return false
+	}
+}
+
 func (s LogLevel) ToLogrusLevel() logrus.Level {
 	switch s {
 	case LogLevelInfo:
@@ -0,0 +1,235 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// "json" | "text" | "logfmt"
+type Format string
+
+const (
+	FormatJSON   Format = "json"
+	FormatText   Format = "text"
+	FormatLogfmt Format = "logfmt"
+)
+
+func (s Format) String() string {
+	return string(s)
+}
+
+func (s *Format) UnmarshalText(text []byte) error {
+	*s = Format(strings.ToLower(strings.TrimSpace(string(text))))
+
+	return nil
+}
+
+// "stdout" | "stderr" | "file"
+type Output string
+
+const (
+	OutputStdout Output = "stdout"
+	OutputStderr Output = "stderr"
+	OutputFile   Output = "file"
+)
+
+func (s Output) String() string {
+	return string(s)
+}
+
+func (s *Output) UnmarshalText(text []byte) error {
+	*s = Output(strings.ToLower(strings.TrimSpace(string(text))))
+
+	return nil
+}
+
+// FileConfig is used by OutputFile. The file is rotated when it reaches
+// MaxSizeMB and, when RotateInterval is set, every RotateInterval. MaxAgeDays
+// and MaxBackups only prune rotated files; they never trigger a rotation.
+type FileConfig struct {
+	Path           string
+	MaxSizeMB      int
+	MaxAgeDays     int
+	MaxBackups     int
+	Compress       bool
+	RotateInterval time.Duration
+}
+
+// rotatingFile rotates the lumberjack file on a ticker, on top of its size limit.
+type rotatingFile struct {
+	*lumberjack.Logger
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+func newRotatingFile(file FileConfig) *rotatingFile {
+	f := &rotatingFile{
+		Logger: &lumberjack.Logger{
+			Filename:   file.Path,
+			MaxSize:    file.MaxSizeMB,
+			MaxAge:     file.MaxAgeDays,
+			MaxBackups: file.MaxBackups,
+			Compress:   file.Compress,
+		},
+	}
+
+	if file.RotateInterval > 0 {
+		f.stop = make(chan struct{})
+		f.done = make(chan struct{})
+
+		go f.run(file.RotateInterval)
+	}
+
+	return f
+}
+
+func (f *rotatingFile) run(interval time.Duration) {
+	defer close(f.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := f.Rotate(); err != nil {
+				fmt.Fprintf(os.Stderr, "failed to rotate log file %s: %v\n", f.Filename, err)
+			}
+		case <-f.stop:
+			return
+		}
+	}
+}
+
+// Close stops the rotation ticker and closes the file.
+func (f *rotatingFile) Close() error {
+	if f.stop != nil {
+		close(f.stop)
+		<-f.done
+	}
+
+	return f.Logger.Close()
+}
+
+var (
+	outputMu     sync.Mutex
+	outputCloser io.Closer
+)
+
+func (c Config) Validate() error {
+	for scope, level := range c.ScopeLevels {
+		if !level.valid() {
+			return fmt.Errorf("unknown log level '%s' for scope '%s'", level, scope)
+		}
+	}
+
+	if c.SlowCall.Threshold < 0 {
+		return fmt.Errorf("slow-call threshold must not be negative")
+	}
+
+	for method, threshold := range c.SlowCall.Methods {
+		if threshold < 0 {
+			return fmt.Errorf("slow-call threshold for '%s' must not be negative", method)
+		}
+	}
+
+	if err := c.Proxy.validate(); err != nil {
+		return err
+	}
+
+	switch c.Format {
+	case "", FormatJSON, FormatText, FormatLogfmt:
+	default:
+		return fmt.Errorf("unknown log format '%s'", c.Format)
+	}
+
+	for _, output := range c.Output {
+		switch output {
+		case OutputStdout, OutputStderr:
+		case OutputFile:
+			if c.File.Path == "" {
+				return fmt.Errorf("log file path is required for the file output")
+			}
+
+			if c.File.RotateInterval < 0 {
+				return fmt.Errorf("log file rotate interval must not be negative")
+			}
+		default:
+			return fmt.Errorf("unknown log output '%s'", output)
+		}
+	}
+
+	return nil
+}
+
+func newFormatter(format Format) logrus.Formatter {
+	switch format {
+	case FormatText:
+		return &logrus.TextFormatter{
+			FullTimestamp:   true,
+			TimestampFormat: time.RFC3339Nano,
+		}
+	case FormatLogfmt:
+		return &logrus.TextFormatter{
+			DisableColors:   true,
+			FullTimestamp:   true,
+			TimestampFormat: time.RFC3339Nano,
+			FieldMap:        defaultFieldMap(),
+		}
+	default:
+		return jsonFormatter()
+	}
+}
+
+// setOutput replaces the logger output and closes the previous log file, if any.
+func setOutput(logger *logrus.Logger, outputs []Output, file FileConfig) {
+	var (
+		writers []io.Writer
+		closer  io.Closer
+	)
+
+	for _, output := range outputs {
+		switch output {
+		case OutputStderr:
+			writers = append(writers, os.Stderr)
+		case OutputFile:
+			if closer != nil || file.Path == "" {
+				continue
+			}
+
+			rotating := newRotatingFile(file)
+
+			writers = append(writers, rotating)
+			closer = rotating
+		case OutputStdout:
+			writers = append(writers, os.Stdout)
+		}
+	}
+
+	switch len(writers) {
+	case 0:
+		logger.SetOutput(os.Stdout)
+	case 1:
+		// A single *os.File keeps terminal detection (and colors) of the text format.
+		logger.SetOutput(writers[0])
+	default:
+		logger.SetOutput(io.MultiWriter(writers...))
+	}
+
+	outputMu.Lock()
+	previous := outputCloser
+	outputCloser = closer
+	outputMu.Unlock()
+
+	if previous != nil {
+		_ = previous.Close()
+	}
+}
@@ -0,0 +1,88 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestSetOutputWritesToFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	log := logrus.New()
+	log.SetFormatter(newFormatter(FormatLogfmt))
+
+	setOutput(log, []Output{OutputFile}, FileConfig{Path: path, MaxSizeMB: 1})
+	t.Cleanup(func() { setOutput(log, nil, FileConfig{}) })
+
+	log.WithField("scope", "test").Info("written to file")
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected read error: %v", err)
+	}
+
+	line := string(data)
+	if !strings.Contains(line, `message="written to file"`) || !strings.Contains(line, "scope=test") {
+		t.Fatalf("unexpected logfmt line: %s", line)
+	}
+}
+
+func TestSetOutputRotatesOnInterval(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	log := logrus.New()
+
+	setOutput(log, []Output{OutputFile}, FileConfig{Path: path, MaxSizeMB: 1, RotateInterval: 20 * time.Millisecond})
+	t.Cleanup(func() { setOutput(log, nil, FileConfig{}) })
+
+	log.Info("before rotation")
+
+	deadline := time.Now().Add(2 * time.Second)
+
+	for {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			t.Fatalf("unexpected read dir error: %v", err)
+		}
+
+		if len(entries) > 1 {
+			break
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the log file to be rotated, got %d files", len(entries))
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	cases := []struct {
+		cfg   Config
+		valid bool
+	}{
+		{cfg: Config{}, valid: true},
+		{cfg: Config{Format: FormatText, Output: []Output{OutputStdout, OutputStderr}}, valid: true},
+		{cfg: Config{Output: []Output{OutputFile}, File: FileConfig{Path: "app.log"}}, valid: true},
+		{cfg: Config{Output: []Output{OutputFile}}, valid: false},
+		{cfg: Config{Output: []Output{OutputFile}, File: FileConfig{Path: "app.log", RotateInterval: -time.Second}}, valid: false},
+		{cfg: Config{Format: "xml"}, valid: false},
+		{cfg: Config{Output: []Output{"syslog"}}, valid: false},
+		{cfg: Config{Proxy: ProxyConfig{Trust: true, TrustedCIDRs: []string{"10.0.0.0/8"}}}, valid: true},
+		{cfg: Config{Proxy: ProxyConfig{Trust: true}}, valid: false},
+		{cfg: Config{Proxy: ProxyConfig{TrustedCIDRs: []string{"proxy.local"}}}, valid: false},
+	}
+
+	for _, tc := range cases {
+		if err := tc.cfg.Validate(); (err == nil) != tc.valid {
+			t.Fatalf("config %+v: expected valid=%v, got %v", tc.cfg, tc.valid, err)
+		}
+	}
+}
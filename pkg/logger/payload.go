@@ -0,0 +1,229 @@
+package logger
+
+import (
+	"strings"
+	"sync/atomic"
+	"unicode/utf8"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	defaultPayloadMaxBytes = 4096
+	redactedValue          = "[REDACTED]"
+)
+
+// Field names that are always redacted. Names match case-insensitively on
+// substrings, ignoring "_" and "-", so password_hash, access_tokens and apiKey
+// are redacted too.
+var defaultRedactFields = []string{
+	"password",
+	"passwd",
+	"secret",
+	"token",
+	"authorization",
+	"api_key",
+	"private_key",
+	"credentials",
+}
+
+// PayloadConfig enables request/response payload logging in the unary logging
+// interceptors. Payloads are marshalled with protojson after redacting fields
+// listed in RedactFields (in addition to the defaults, matched the same way)
+// and fields marked with the debug_redact option, and are cut to MaxBytes.
+// Any payloads are unpacked and Struct keys are redacted like field names.
+type PayloadConfig struct {
+	Enabled      bool
+	MaxBytes     int
+	RedactFields []string
+	Level        LogLevel
+}
+
+type payloadSettings struct {
+	level    logrus.Level
+	maxBytes int
+	redact   []string
+}
+
+var payloadRef atomic.Pointer[payloadSettings]
+
+func setPayloadConfig(cfg PayloadConfig) {
+	if !cfg.Enabled {
+		payloadRef.Store(nil)
+		return
+	}
+
+	settings := &payloadSettings{
+		level:    LogLevelTrace.ToLogrusLevel(),
+		maxBytes: cfg.MaxBytes,
+	}
+
+	if cfg.Level != "" {
+		settings.level = cfg.Level.ToLogrusLevel()
+	}
+
+	if settings.maxBytes <= 0 {
+		settings.maxBytes = defaultPayloadMaxBytes
+	}
+
+	for _, name := range append(append([]string{}, defaultRedactFields...), cfg.RedactFields...) {
+		if name = normalizeFieldName(name); name != "" {
+			settings.redact = append(settings.redact, name)
+		}
+	}
+
+	payloadRef.Store(settings)
+}
+
+// logPayload logs msg as "payload" on entry when payload logging is enabled
+// and the configured level is enabled.
+func logPayload(entry *logrus.Entry, msg any, format string, args ...any) {
+	settings := payloadRef.Load()
+	if settings == nil || !scopeLevelEnabled(entry.Logger, scopeOf(entry), settings.level) {
+		return
+	}
+
+	pm, ok := msg.(proto.Message)
+	if !ok || pm == nil {
+		return
+	}
+
+	entry.WithField("payload", settings.marshal(pm)).Logf(settings.level, format, args...)
+}
+
+func (s *payloadSettings) marshal(msg proto.Message) string {
+	redacted := proto.Clone(msg)
+	s.redactMessage(redacted.ProtoReflect())
+
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(redacted)
+	if err != nil {
+		return "<failed to marshal payload: " + err.Error() + ">"
+	}
+
+	if len(data) > s.maxBytes {
+		// Back off to a rune boundary, so a multi-byte character is not split.
+		cut := s.maxBytes
+		for cut > 0 && !utf8.RuneStart(data[cut]) {
+			cut--
+		}
+
+		return string(data[:cut]) + "...(truncated)"
+	}
+
+	return string(data)
+}
+
+func (s *payloadSettings) redactMessage(m protoreflect.Message) {
+	switch msg := m.Interface().(type) {
+	case *anypb.Any:
+		s.redactAny(msg)
+		return
+	case *structpb.Struct:
+		s.redactStruct(msg)
+		return
+	case *structpb.Value:
+		s.redactValue(msg)
+		return
+	}
+
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if s.isRedacted(fd) {
+			redactField(m, fd)
+			return true
+		}
+
+		switch {
+		case fd.IsList() && fd.Message() != nil:
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				s.redactMessage(list.Get(i).Message())
+			}
+		case fd.IsMap() && fd.MapValue().Message() != nil:
+			v.Map().Range(func(_ protoreflect.MapKey, mv protoreflect.Value) bool {
+				s.redactMessage(mv.Message())
+				return true
+			})
+		case !fd.IsList() && !fd.IsMap() && fd.Message() != nil:
+			s.redactMessage(v.Message())
+		}
+
+		return true
+	})
+}
+
+// redactAny redacts the packed message in place. Payloads of unknown types
+// are left packed; protojson cannot marshal them either.
+func (s *payloadSettings) redactAny(msg *anypb.Any) {
+	inner, err := msg.UnmarshalNew()
+	if err != nil {
+		return
+	}
+
+	s.redactMessage(inner.ProtoReflect())
+	_ = msg.MarshalFrom(inner)
+}
+
+func (s *payloadSettings) redactStruct(msg *structpb.Struct) {
+	for key, value := range msg.GetFields() {
+		if s.isRedactedName(key) {
+			msg.Fields[key] = structpb.NewStringValue(redactedValue)
+			continue
+		}
+
+		s.redactValue(value)
+	}
+}
+
+func (s *payloadSettings) redactValue(value *structpb.Value) {
+	switch kind := value.GetKind().(type) {
+	case *structpb.Value_StructValue:
+		s.redactStruct(kind.StructValue)
+	case *structpb.Value_ListValue:
+		for _, item := range kind.ListValue.GetValues() {
+			s.redactValue(item)
+		}
+	}
+}
+
+func (s *payloadSettings) isRedacted(fd protoreflect.FieldDescriptor) bool {
+	if opts, ok := fd.Options().(*descriptorpb.FieldOptions); ok && opts.GetDebugRedact() {
+		return true
+	}
+
+	return s.isRedactedName(string(fd.Name()))
+}
+
+func (s *payloadSettings) isRedactedName(name string) bool {
+	name = normalizeFieldName(name)
+
+	for _, redact := range s.redact {
+		if strings.Contains(name, redact) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// normalizeFieldName lowercases name and drops "_" and "-", so api_key,
+// apiKey and API-KEY compare equal.
+func normalizeFieldName(name string) string {
+	return strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(strings.TrimSpace(name)))
+}
+
+// redactField replaces string values with a marker and clears everything else,
+// so the field name stays visible only when it carries a string.
+func redactField(m protoreflect.Message, fd protoreflect.FieldDescriptor) {
+	if fd.Kind() == protoreflect.StringKind && !fd.IsList() && !fd.IsMap() {
+		m.Set(fd, protoreflect.ValueOfString(redactedValue))
+		return
+	}
+
+	m.Clear(fd)
+}
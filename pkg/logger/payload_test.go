@@ -0,0 +1,154 @@
+package logger
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	identity_v1 "github.com/invenlore/proto/pkg/identity/v1"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestLogPayloadRedactsSensitiveFields(t *testing.T) {
+	setPayloadConfig(PayloadConfig{Enabled: true, RedactFields: []string{"Name"}})
+	t.Cleanup(func() { setPayloadConfig(PayloadConfig{}) })
+
+	log, hook := test.NewNullLogger()
+	log.SetLevel(logrus.TraceLevel)
+
+	logPayload(logrus.NewEntry(log), &identity_v1.RegisterRequest{
+		Email:    "user@example.com",
+		Password: "hunter2",
+		Name:     "User",
+	}, "request payload")
+
+	logPayload(logrus.NewEntry(log), &identity_v1.RefreshResponse{
+		AccessToken:      "access",
+		RefreshToken:     "refresh",
+		ExpiresInSeconds: 900,
+	}, "response payload")
+
+	entries := hook.AllEntries()
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 payload entries, got %d", len(entries))
+	}
+
+	request, _ := entries[0].Data["payload"].(string)
+	if strings.Contains(request, "hunter2") || strings.Contains(request, `"User"`) {
+		t.Fatalf("expected password and name to be redacted, got %s", request)
+	}
+
+	if !strings.Contains(request, "user@example.com") {
+		t.Fatalf("expected email to be kept, got %s", request)
+	}
+
+	response, _ := entries[1].Data["payload"].(string)
+	if strings.Contains(response, `"access"`) || strings.Contains(response, `"refresh"`) {
+		t.Fatalf("expected tokens to be redacted, got %s", response)
+	}
+
+	if !strings.Contains(response, "900") {
+		t.Fatalf("expected expires_in_seconds to be kept, got %s", response)
+	}
+
+	if entries[0].Level != logrus.TraceLevel {
+		t.Fatalf("expected payloads at TRACE by default, got %s", entries[0].Level)
+	}
+}
+
+func TestLogPayloadRespectsLevelAndSize(t *testing.T) {
+	setPayloadConfig(PayloadConfig{Enabled: true, Level: LogLevelDebug, MaxBytes: 16})
+	t.Cleanup(func() { setPayloadConfig(PayloadConfig{}) })
+
+	log, hook := test.NewNullLogger()
+	msg := &identity_v1.RegisterRequest{Email: strings.Repeat("a", 64) + "@example.com"}
+
+	log.SetLevel(logrus.InfoLevel)
+	logPayload(logrus.NewEntry(log), msg, "request payload")
+
+	if len(hook.AllEntries()) != 0 {
+		t.Fatalf("expected no payload below the configured level")
+	}
+
+	log.SetLevel(logrus.DebugLevel)
+	logPayload(logrus.NewEntry(log), msg, "request payload")
+
+	payload, _ := hook.LastEntry().Data["payload"].(string)
+	if !strings.HasSuffix(payload, "...(truncated)") || len(payload) != 16+len("...(truncated)") {
+		t.Fatalf("expected truncated payload, got %s", payload)
+	}
+}
+
+func TestLogPayloadTruncatesAtRuneBoundary(t *testing.T) {
+	// {"email":" is 10 bytes, so a 15 byte cut lands inside the third "é".
+	setPayloadConfig(PayloadConfig{Enabled: true, Level: LogLevelDebug, MaxBytes: 15})
+	t.Cleanup(func() { setPayloadConfig(PayloadConfig{}) })
+
+	log, hook := test.NewNullLogger()
+	log.SetLevel(logrus.DebugLevel)
+
+	logPayload(logrus.NewEntry(log), &identity_v1.RegisterRequest{Email: strings.Repeat("é", 32)}, "request payload")
+
+	payload, _ := hook.LastEntry().Data["payload"].(string)
+	if !utf8.ValidString(payload) {
+		t.Fatalf("expected valid UTF-8 payload, got %q", payload)
+	}
+
+	if expected := `{"email":"éé...(truncated)`; payload != expected {
+		t.Fatalf("expected %q, got %q", expected, payload)
+	}
+}
+
+func TestLogPayloadRedactsNestedAndDerivedNames(t *testing.T) {
+	setPayloadConfig(PayloadConfig{Enabled: true})
+	t.Cleanup(func() { setPayloadConfig(PayloadConfig{}) })
+
+	nested, err := structpb.NewStruct(map[string]any{
+		"user":          "alice",
+		"password_hash": "hash-value",
+		"access_tokens": []any{"token-1", "token-2"},
+		"apiKey":        "key-value",
+		"profile": map[string]any{
+			"Client-Secret": "secret-value",
+			"city":          "Berlin",
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected struct error: %v", err)
+	}
+
+	packed, err := anypb.New(&identity_v1.RegisterRequest{Email: "user@example.com", Password: "hunter2"})
+	if err != nil {
+		t.Fatalf("unexpected any error: %v", err)
+	}
+
+	log, hook := test.NewNullLogger()
+	log.SetLevel(logrus.TraceLevel)
+
+	logPayload(logrus.NewEntry(log), nested, "struct payload")
+	logPayload(logrus.NewEntry(log), packed, "any payload")
+
+	entries := hook.AllEntries()
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 payload entries, got %d", len(entries))
+	}
+
+	structPayload, _ := entries[0].Data["payload"].(string)
+	for _, leaked := range []string{"hash-value", "token-1", "key-value", "secret-value"} {
+		if strings.Contains(structPayload, leaked) {
+			t.Fatalf("expected %q to be redacted, got %s", leaked, structPayload)
+		}
+	}
+
+	if !strings.Contains(structPayload, "alice") || !strings.Contains(structPayload, "Berlin") {
+		t.Fatalf("expected other struct values to be kept, got %s", structPayload)
+	}
+
+	anyPayload, _ := entries[1].Data["payload"].(string)
+	if strings.Contains(anyPayload, "hunter2") || !strings.Contains(anyPayload, "user@example.com") {
+		t.Fatalf("expected the packed password to be redacted, got %s", anyPayload)
+	}
+}
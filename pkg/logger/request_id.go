@@ -0,0 +1,60 @@
+package logger
+
+import (
+	"context"
+
+	"google.golang.org/grpc/metadata"
+)
+
+type requestIDCtxKey struct{}
+
+// WithRequestID stores the request ID in ctx.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDCtxKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request ID stored by WithRequestID,
+// falling back to the legacy RequestIDCtxKey value.
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+
+	if requestID, ok := ctx.Value(requestIDCtxKey{}).(string); ok && requestID != "" {
+		return requestID
+	}
+
+	if requestID, ok := ctx.Value(RequestIDCtxKey).(string); ok && requestID != "" {
+		return requestID
+	}
+
+	return ""
+}
+
+func outgoingRequestID(ctx context.Context) string {
+	if requestID := RequestIDFromContext(ctx); requestID != "" {
+		return requestID
+	}
+
+	if md, ok := metadata.FromOutgoingContext(ctx); ok {
+		if vals := md.Get(RequestIDMDKey); len(vals) > 0 && vals[0] != "" {
+			return vals[0]
+		}
+	}
+
+	return ""
+}
+
+func incomingRequestID(ctx context.Context) string {
+	if requestID := RequestIDFromContext(ctx); requestID != "" {
+		return requestID
+	}
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if vals := md.Get(RequestIDMDKey); len(vals) > 0 && vals[0] != "" {
+			return vals[0]
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,28 @@
+package logger
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRequestIDFromContext(t *testing.T) {
+	ctx := WithRequestID(context.Background(), "req-1")
+	if got := RequestIDFromContext(ctx); got != "req-1" {
+		t.Fatalf("expected req-1, got %q", got)
+	}
+
+	//nolint:staticcheck // legacy key must keep working during the migration.
+	legacy := context.WithValue(context.Background(), RequestIDCtxKey, "req-2")
+	if got := RequestIDFromContext(legacy); got != "req-2" {
+		t.Fatalf("expected legacy req-2, got %q", got)
+	}
+
+	// The typed key wins over the legacy one.
+	if got := RequestIDFromContext(WithRequestID(legacy, "req-3")); got != "req-3" {
+		t.Fatalf("expected req-3, got %q", got)
+	}
+
+	if got := RequestIDFromContext(context.Background()); got != "" {
+		t.Fatalf("expected empty request ID, got %q", got)
+	}
+}
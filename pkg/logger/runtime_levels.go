@@ -0,0 +1,152 @@
+package logger
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Levels is the current base level and per-scope overrides. RevertAt is set
+// while a runtime change with an auto-revert is active.
+type Levels struct {
+	Level       LogLevel    `json:"level"`
+	ScopeLevels ScopeLevels `json:"scope_levels,omitempty"`
+	RevertAt    *time.Time  `json:"revert_at,omitempty"`
+}
+
+var (
+	levelsMu    sync.Mutex
+	configured  Levels
+	current     Levels
+	revertTimer *time.Timer
+	revertGen   uint64
+)
+
+// setConfiguredLevels records the levels from Init as the ones ResetLevels and
+// auto-revert go back to, and cancels any pending revert.
+func setConfiguredLevels(level LogLevel, scopes ScopeLevels) {
+	levelsMu.Lock()
+	defer levelsMu.Unlock()
+
+	stopRevertLocked()
+
+	configured = Levels{Level: level, ScopeLevels: copyScopeLevels(scopes)}
+	current = configured
+
+	applyLevels(logrus.StandardLogger(), level, scopes)
+}
+
+func GetLevels() Levels {
+	levelsMu.Lock()
+	defer levelsMu.Unlock()
+
+	levels := current
+	levels.ScopeLevels = copyScopeLevels(current.ScopeLevels)
+
+	return levels
+}
+
+// SetLevels changes the base level and replaces the per-scope overrides at
+// runtime. With revertAfter > 0 the levels from Init are restored after that
+// duration; a later SetLevels or ResetLevels cancels the pending revert.
+func SetLevels(level LogLevel, scopes ScopeLevels, revertAfter time.Duration) (Levels, error) {
+	if !level.valid() {
+		return Levels{}, fmt.Errorf("unknown log level '%s'", level)
+	}
+
+	for scope, lvl := range scopes {
+		if !lvl.valid() {
+			return Levels{}, fmt.Errorf("unknown log level '%s' for scope '%s'", lvl, scope)
+		}
+	}
+
+	levelsMu.Lock()
+	defer levelsMu.Unlock()
+
+	stopRevertLocked()
+
+	previous := current.Level
+	current = Levels{Level: level, ScopeLevels: copyScopeLevels(scopes)}
+
+	if revertAfter > 0 {
+		revertAt := time.Now().Add(revertAfter)
+		current.RevertAt = &revertAt
+
+		gen := revertGen
+		revertTimer = time.AfterFunc(revertAfter, func() { revertLevels(gen) })
+	}
+
+	applyLevels(logrus.StandardLogger(), level, scopes)
+
+	logrus.WithFields(logrus.Fields{
+		"scope":          "logger",
+		"new_level":      level,
+		"previous_level": previous,
+		"scope_levels":   scopes,
+		"revert_after":   revertAfter.String(),
+	}).Warn("log levels changed at runtime")
+
+	return current, nil
+}
+
+// ResetLevels restores the levels from Init.
+func ResetLevels() Levels {
+	levelsMu.Lock()
+	defer levelsMu.Unlock()
+
+	stopRevertLocked()
+	resetLocked()
+
+	return current
+}
+
+func revertLevels(gen uint64) {
+	levelsMu.Lock()
+	defer levelsMu.Unlock()
+
+	// The timer fired after it was replaced by a newer change.
+	if revertTimer == nil || revertGen != gen {
+		return
+	}
+
+	revertTimer = nil
+	previous := current.Level
+	resetLocked()
+
+	logrus.WithFields(logrus.Fields{
+		"scope":          "logger",
+		"new_level":      current.Level,
+		"previous_level": previous,
+	}).Warn("log levels reverted to configured values")
+}
+
+func resetLocked() {
+	current = configured
+	current.ScopeLevels = copyScopeLevels(configured.ScopeLevels)
+
+	applyLevels(logrus.StandardLogger(), configured.Level, configured.ScopeLevels)
+}
+
+func stopRevertLocked() {
+	revertGen++
+
+	if revertTimer != nil {
+		revertTimer.Stop()
+		revertTimer = nil
+	}
+}
+
+func copyScopeLevels(scopes ScopeLevels) ScopeLevels {
+	if len(scopes) == 0 {
+		return nil
+	}
+
+	copied := make(ScopeLevels, len(scopes))
+	for scope, level := range scopes {
+		copied[scope] = level
+	}
+
+	return copied
+}
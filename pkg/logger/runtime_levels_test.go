@@ -0,0 +1,55 @@
+package logger
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestSetLevelsRevertsToConfigured(t *testing.T) {
+	std := logrus.StandardLogger()
+	prevOut, prevLevel := std.Out, std.GetLevel()
+
+	t.Cleanup(func() {
+		ResetLevels()
+		levelsRef.Store(nil)
+		std.SetOutput(prevOut)
+		std.SetLevel(prevLevel)
+	})
+
+	std.SetOutput(io.Discard)
+	setConfiguredLevels(LogLevelInfo, ScopeLevels{"health": LogLevelWarn})
+
+	if _, err := SetLevels("LOUD", nil, 0); err == nil {
+		t.Fatalf("expected error for unknown level")
+	}
+
+	levels, err := SetLevels(LogLevelDebug, ScopeLevels{"gRPC": LogLevelTrace}, 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if levels.RevertAt == nil || std.GetLevel() != logrus.TraceLevel {
+		t.Fatalf("unexpected levels after change: %+v (logger at %s)", levels, std.GetLevel())
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for GetLevels().RevertAt != nil {
+		if time.Now().After(deadline) {
+			t.Fatalf("levels were not reverted")
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	got := GetLevels()
+	if got.Level != LogLevelInfo || got.ScopeLevels["health"] != LogLevelWarn || len(got.ScopeLevels) != 1 {
+		t.Fatalf("expected configured levels after revert, got %+v", got)
+	}
+
+	if std.GetLevel() != logrus.InfoLevel {
+		t.Fatalf("expected logger at info after revert, got %s", std.GetLevel())
+	}
+}
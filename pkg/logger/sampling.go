@@ -0,0 +1,235 @@
+package logger
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const maxSamplingKeys = 4096
+
+// SamplingConfig limits repeated WARN and ERROR lines. Lines are grouped by
+// scope, rpc_method, grpc_code and message; within every Interval the first
+// Initial lines of a group are written, then every Thereafter-th line.
+// The next written line of a group is preceded by a summary with the number
+// of suppressed lines; groups that go quiet get their summary once their
+// Interval expires. Summary lines carry a "suppressed" field and are never sampled.
+type SamplingConfig struct {
+	Enabled    bool
+	Initial    int
+	Thereafter int
+	Interval   time.Duration
+}
+
+type sampleCounter struct {
+	windowStart time.Time
+	count       int
+	suppressed  int
+	last        *logrus.Entry
+}
+
+// samplingFormatter drops lines by returning empty output, which is the only
+// way to filter entries in logrus: hooks cannot stop an entry from being written.
+type samplingFormatter struct {
+	next     logrus.Formatter
+	cfg      SamplingConfig
+	now      func() time.Time
+	mu       sync.Mutex
+	counters map[string]*sampleCounter
+}
+
+func newSamplingFormatter(next logrus.Formatter, cfg SamplingConfig) *samplingFormatter {
+	return &samplingFormatter{
+		next:     next,
+		cfg:      cfg,
+		now:      time.Now,
+		counters: make(map[string]*sampleCounter),
+	}
+}
+
+func (f *samplingFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	if entry.Level != logrus.ErrorLevel && entry.Level != logrus.WarnLevel {
+		return f.next.Format(entry)
+	}
+
+	if _, ok := entry.Data["suppressed"]; ok {
+		return f.next.Format(entry)
+	}
+
+	keep, suppressed := f.sample(sampleKey(entry), entry)
+	if !keep {
+		return nil, nil
+	}
+
+	if suppressed == 0 {
+		return f.next.Format(entry)
+	}
+
+	summary, err := f.next.Format(summaryEntry(entry, suppressed))
+	if err != nil {
+		return nil, err
+	}
+
+	line, err := f.next.Format(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(summary, line...), nil
+}
+
+// sample reports whether the line is written and, if so, how many lines of
+// the same group were suppressed since the last written one.
+func (f *samplingFormatter) sample(key string, entry *logrus.Entry) (bool, int) {
+	now := f.now()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	c, ok := f.counters[key]
+	if !ok || now.Sub(c.windowStart) >= f.cfg.Interval {
+		if !ok && len(f.counters) >= maxSamplingKeys {
+			f.cleanupLocked(now)
+		}
+
+		suppressed := 0
+		if ok {
+			suppressed = c.suppressed
+		}
+
+		f.counters[key] = &sampleCounter{windowStart: now, count: 1}
+
+		return true, suppressed
+	}
+
+	c.count++
+
+	over := c.count - f.cfg.Initial
+	if over <= 0 || (f.cfg.Thereafter > 0 && over%f.cfg.Thereafter == 0) {
+		suppressed := c.suppressed
+		c.suppressed = 0
+
+		return true, suppressed
+	}
+
+	c.suppressed++
+	c.last = snapshotEntry(entry)
+
+	return false, 0
+}
+
+// flush writes the summaries of groups whose window expired with suppressed
+// lines, which would otherwise only be reported by the next line of the group.
+func (f *samplingFormatter) flush() {
+	now := f.now()
+
+	var summaries []*logrus.Entry
+
+	f.mu.Lock()
+
+	for key, c := range f.counters {
+		if now.Sub(c.windowStart) < f.cfg.Interval {
+			continue
+		}
+
+		if c.suppressed > 0 && c.last != nil {
+			summary := summaryEntry(c.last, c.suppressed)
+			summary.Time = now
+			summaries = append(summaries, summary)
+		}
+
+		delete(f.counters, key)
+	}
+
+	f.mu.Unlock()
+
+	for _, summary := range summaries {
+		summary.Log(summary.Level, summary.Message)
+	}
+}
+
+func (f *samplingFormatter) run(stop <-chan struct{}) {
+	t := time.NewTicker(f.cfg.Interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-t.C:
+			f.flush()
+		}
+	}
+}
+
+var (
+	samplingMu   sync.Mutex
+	stopSampling func()
+)
+
+// setSamplingFormatter flushes the summaries of f every Interval, replacing
+// the flusher of a previous Init. A nil f only stops the previous flusher.
+func setSamplingFormatter(f *samplingFormatter) {
+	samplingMu.Lock()
+	defer samplingMu.Unlock()
+
+	if stopSampling != nil {
+		stopSampling()
+		stopSampling = nil
+	}
+
+	if f == nil || f.cfg.Interval <= 0 {
+		return
+	}
+
+	stop := make(chan struct{})
+	go f.run(stop)
+
+	stopSampling = func() { close(stop) }
+}
+
+// snapshotEntry keeps what summaryEntry needs after the entry is written.
+func snapshotEntry(entry *logrus.Entry) *logrus.Entry {
+	snapshot := entry.Dup()
+	snapshot.Level = entry.Level
+	snapshot.Message = entry.Message
+
+	return snapshot
+}
+
+func (f *samplingFormatter) cleanupLocked(now time.Time) {
+	for key, c := range f.counters {
+		if now.Sub(c.windowStart) >= f.cfg.Interval {
+			delete(f.counters, key)
+		}
+	}
+}
+
+func sampleKey(entry *logrus.Entry) string {
+	var b strings.Builder
+
+	for _, field := range []string{"scope", "rpc_method", "grpc_code"} {
+		if v, ok := entry.Data[field]; ok {
+			fmt.Fprint(&b, v)
+		}
+
+		b.WriteByte('|')
+	}
+
+	b.WriteString(entry.Message)
+
+	return b.String()
+}
+
+func summaryEntry(entry *logrus.Entry, suppressed int) *logrus.Entry {
+	summary := entry.Dup()
+	summary.Data["suppressed"] = suppressed
+	summary.Level = entry.Level
+	summary.Time = entry.Time
+	summary.Message = fmt.Sprintf("suppressed %d similar messages: %s", suppressed, entry.Message)
+
+	return summary
+}
@@ -0,0 +1,110 @@
+package logger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+)
+
+func TestSamplingFormatterSuppressesRepeatedErrors(t *testing.T) {
+	now := time.Unix(0, 0)
+
+	formatter := newSamplingFormatter(&logrus.TextFormatter{DisableTimestamp: true}, SamplingConfig{
+		Enabled:    true,
+		Initial:    2,
+		Thereafter: 3,
+		Interval:   time.Minute,
+	})
+	formatter.now = func() time.Time { return now }
+
+	var out bytes.Buffer
+
+	log := logrus.New()
+	log.SetOutput(&out)
+	log.SetFormatter(formatter)
+
+	entry := log.WithFields(logrus.Fields{
+		"scope":      "gRPC",
+		"rpc_method": "/test.v1.TestService/Get",
+		"grpc_code":  codes.Unavailable,
+	})
+
+	// 1, 2 initial; 5 is the first 1-in-3 line after them.
+	for i := 0; i < 6; i++ {
+		entry.Error("client: gRPC request failed")
+	}
+
+	log.WithField("scope", "other").Error("client: gRPC request failed")
+	log.Info("info lines are never sampled")
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 6 {
+		t.Fatalf("expected 6 lines, got %d:\n%s", len(lines), out.String())
+	}
+
+	if !strings.Contains(lines[2], "suppressed 2 similar messages") {
+		t.Fatalf("expected summary before the sampled line, got %s", lines[2])
+	}
+
+	out.Reset()
+	now = now.Add(time.Minute)
+
+	entry.Error("client: gRPC request failed")
+
+	lines = strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], "suppressed 1 similar messages") {
+		t.Fatalf("expected summary when the window resets, got:\n%s", out.String())
+	}
+}
+
+func TestSamplingFormatterFlushesExpiredSummaries(t *testing.T) {
+	now := time.Unix(0, 0)
+
+	formatter := newSamplingFormatter(&logrus.TextFormatter{DisableTimestamp: true}, SamplingConfig{
+		Enabled:  true,
+		Initial:  1,
+		Interval: time.Minute,
+	})
+	formatter.now = func() time.Time { return now }
+
+	var out bytes.Buffer
+
+	log := logrus.New()
+	log.SetOutput(&out)
+	log.SetFormatter(formatter)
+
+	// The group goes quiet after a burst: nothing else would report it.
+	for i := 0; i < 4; i++ {
+		log.WithField("scope", "gRPC").Warn("retrying")
+	}
+
+	formatter.flush()
+
+	if strings.Contains(out.String(), "suppressed") {
+		t.Fatalf("expected no summary before the window expires, got:\n%s", out.String())
+	}
+
+	now = now.Add(time.Minute)
+	formatter.flush()
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[1], "suppressed 3 similar messages: retrying") {
+		t.Fatalf("expected the summary to be flushed, got:\n%s", out.String())
+	}
+
+	if !strings.Contains(lines[1], "scope=gRPC") || !strings.Contains(lines[1], "level=warning") {
+		t.Fatalf("expected the summary to keep the group fields and level, got %s", lines[1])
+	}
+
+	out.Reset()
+	formatter.flush()
+	log.WithField("scope", "gRPC").Warn("retrying")
+
+	if strings.Contains(out.String(), "suppressed") {
+		t.Fatalf("expected the flushed summary not to be repeated, got:\n%s", out.String())
+	}
+}
@@ -0,0 +1,115 @@
+package logger
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ScopeLevels maps a "scope" field value to its level. It is parsed from
+// "scope=level" pairs separated by commas, e.g. "gRPC=trace,health=warn".
+type ScopeLevels map[string]LogLevel
+
+func (s *ScopeLevels) UnmarshalText(text []byte) error {
+	levels := make(ScopeLevels)
+
+	for _, pair := range strings.Split(string(text), ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+
+		scope, level, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(scope) == "" {
+			return fmt.Errorf("invalid scope level '%s', expected scope=level", pair)
+		}
+
+		var lvl LogLevel
+		if err := lvl.UnmarshalText([]byte(strings.TrimSpace(level))); err != nil {
+			return err
+		}
+
+		levels[strings.TrimSpace(scope)] = lvl
+	}
+
+	*s = levels
+
+	return nil
+}
+
+// levelSettings is the base level plus per-scope overrides, keyed by the
+// lower-cased "scope" field. The logger itself runs at the most verbose of
+// these levels and levelFormatter drops what a scope does not want.
+type levelSettings struct {
+	base   logrus.Level
+	scopes map[string]logrus.Level
+}
+
+var levelsRef atomic.Pointer[levelSettings]
+
+func applyLevels(logger *logrus.Logger, base LogLevel, scopes ScopeLevels) {
+	settings := &levelSettings{
+		base:   base.ToLogrusLevel(),
+		scopes: make(map[string]logrus.Level, len(scopes)),
+	}
+
+	verbose := settings.base
+
+	for scope, level := range scopes {
+		lvl := level.ToLogrusLevel()
+		settings.scopes[strings.ToLower(scope)] = lvl
+
+		if lvl > verbose {
+			verbose = lvl
+		}
+	}
+
+	levelsRef.Store(settings)
+	logger.SetLevel(verbose)
+}
+
+func (s *levelSettings) level(scope string) logrus.Level {
+	if lvl, ok := s.scopes[strings.ToLower(scope)]; ok {
+		return lvl
+	}
+
+	return s.base
+}
+
+// scopeLevelEnabled is logrus' IsLevelEnabled that also honours the scope override,
+// for callers that want to skip expensive work such as marshalling payloads.
+func scopeLevelEnabled(logger *logrus.Logger, scope string, level logrus.Level) bool {
+	if !logger.IsLevelEnabled(level) {
+		return false
+	}
+
+	settings := levelsRef.Load()
+	if settings == nil || logger != logrus.StandardLogger() {
+		return true
+	}
+
+	return settings.level(scope) >= level
+}
+
+func scopeOf(entry *logrus.Entry) string {
+	scope, _ := entry.Data["scope"].(string)
+	return scope
+}
+
+type levelFormatter struct {
+	next logrus.Formatter
+}
+
+func (f *levelFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	settings := levelsRef.Load()
+	if settings == nil || entry.Logger != logrus.StandardLogger() {
+		return f.next.Format(entry)
+	}
+
+	if entry.Level > settings.level(scopeOf(entry)) {
+		return nil, nil
+	}
+
+	return f.next.Format(entry)
+}
@@ -0,0 +1,59 @@
+package logger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestScopeLevelsOverrideBaseLevel(t *testing.T) {
+	std := logrus.StandardLogger()
+	prevOut, prevFormatter, prevLevel := std.Out, std.Formatter, std.GetLevel()
+
+	t.Cleanup(func() {
+		levelsRef.Store(nil)
+		std.SetOutput(prevOut)
+		std.SetFormatter(prevFormatter)
+		std.SetLevel(prevLevel)
+	})
+
+	var out bytes.Buffer
+
+	std.SetOutput(&out)
+	std.SetFormatter(&levelFormatter{next: &logrus.TextFormatter{DisableTimestamp: true}})
+
+	applyLevels(std, LogLevelInfo, ScopeLevels{
+		"gRPC":   LogLevelTrace,
+		"health": LogLevelWarn,
+	})
+
+	if std.GetLevel() != logrus.TraceLevel {
+		t.Fatalf("expected logger to run at the most verbose level, got %s", std.GetLevel())
+	}
+
+	logrus.WithField("scope", "gRPC").Trace("grpc trace")
+	logrus.WithField("scope", "health").Info("health info")
+	logrus.WithField("scope", "health").Warn("health warn")
+	logrus.WithField("scope", "config").Debug("config debug")
+	logrus.Info("plain info")
+
+	got := out.String()
+
+	for _, kept := range []string{"grpc trace", "health warn", "plain info"} {
+		if !strings.Contains(got, kept) {
+			t.Fatalf("expected %q to be logged, got:\n%s", kept, got)
+		}
+	}
+
+	for _, dropped := range []string{"health info", "config debug"} {
+		if strings.Contains(got, dropped) {
+			t.Fatalf("expected %q to be dropped, got:\n%s", dropped, got)
+		}
+	}
+
+	if !scopeLevelEnabled(std, "grpc", logrus.TraceLevel) || scopeLevelEnabled(std, "config", logrus.DebugLevel) {
+		t.Fatalf("unexpected scopeLevelEnabled result")
+	}
+}
@@ -0,0 +1,140 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// slogHandler is a slog.Handler that writes through a logrus logger, so slog
+// records get the same level filtering, formatter, outputs and hooks
+// (default fields, trace IDs) as everything else.
+type slogHandler struct {
+	logger *logrus.Logger
+	fields logrus.Fields
+	group  string
+}
+
+// NewSlogHandler returns a slog.Handler backed by logger, or by the standard
+// logrus logger when logger is nil. Init installs one as the slog default.
+func NewSlogHandler(logger *logrus.Logger) slog.Handler {
+	if logger == nil {
+		logger = logrus.StandardLogger()
+	}
+
+	return &slogHandler{logger: logger, fields: logrus.Fields{}}
+}
+
+// NewSlogLogger returns a *slog.Logger for libraries that take one, with the
+// "scope" field set so per-scope levels apply to it.
+func NewSlogLogger(scope string) *slog.Logger {
+	return slog.New(NewSlogHandler(nil)).With("scope", scope)
+}
+
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	scope, _ := h.fields["scope"].(string)
+
+	return scopeLevelEnabled(h.logger, scope, slogToLogrusLevel(level))
+}
+
+func (h *slogHandler) Handle(ctx context.Context, record slog.Record) error {
+	fields := make(logrus.Fields, len(h.fields)+record.NumAttrs())
+	for key, value := range h.fields {
+		fields[key] = value
+	}
+
+	record.Attrs(func(attr slog.Attr) bool {
+		addSlogAttr(fields, h.group, attr)
+		return true
+	})
+
+	entry := logrus.NewEntry(h.logger).WithFields(fields)
+
+	if ctx != nil {
+		entry = entry.WithContext(ctx)
+	}
+
+	if !record.Time.IsZero() {
+		entry = entry.WithTime(record.Time)
+	}
+
+	entry.Log(slogToLogrusLevel(record.Level), record.Message)
+
+	return nil
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	fields := make(logrus.Fields, len(h.fields)+len(attrs))
+	for key, value := range h.fields {
+		fields[key] = value
+	}
+
+	for _, attr := range attrs {
+		addSlogAttr(fields, h.group, attr)
+	}
+
+	return &slogHandler{logger: h.logger, fields: fields, group: h.group}
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	return &slogHandler{logger: h.logger, fields: h.fields, group: groupKey(h.group, name)}
+}
+
+// addSlogAttr flattens groups into dotted keys, e.g. "http.status".
+func addSlogAttr(fields logrus.Fields, group string, attr slog.Attr) {
+	attr.Value = attr.Value.Resolve()
+
+	if attr.Equal(slog.Attr{}) {
+		return
+	}
+
+	if attr.Value.Kind() == slog.KindGroup {
+		prefix := group
+		if attr.Key != "" {
+			prefix = groupKey(group, attr.Key)
+		}
+
+		for _, groupAttr := range attr.Value.Group() {
+			addSlogAttr(fields, prefix, groupAttr)
+		}
+
+		return
+	}
+
+	fields[groupKey(group, attr.Key)] = attr.Value.Any()
+}
+
+func groupKey(group, key string) string {
+	if group == "" {
+		return key
+	}
+
+	return strings.Join([]string{group, key}, ".")
+}
+
+// slogToLogrusLevel maps levels below slog.LevelDebug to TRACE and levels above
+// slog.LevelError to ERROR, so slog never triggers logrus Fatal or Panic.
+func slogToLogrusLevel(level slog.Level) logrus.Level {
+	switch {
+	case level < slog.LevelDebug:
+		return logrus.TraceLevel
+	case level < slog.LevelInfo:
+		return logrus.DebugLevel
+	case level < slog.LevelWarn:
+		return logrus.InfoLevel
+	case level < slog.LevelError:
+		return logrus.WarnLevel
+	default:
+		return logrus.ErrorLevel
+	}
+}
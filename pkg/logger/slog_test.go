@@ -0,0 +1,45 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestSlogHandlerWritesThroughLogrus(t *testing.T) {
+	var out bytes.Buffer
+
+	l := logrus.New()
+	l.SetOutput(&out)
+	l.SetFormatter(jsonFormatter())
+	l.SetLevel(logrus.InfoLevel)
+	l.AddHook(defaultFieldsHook{fields: logrus.Fields{"service": "svc"}})
+
+	log := slog.New(NewSlogHandler(l)).With("scope", "lib").WithGroup("http")
+
+	log.Debug("dropped")
+	log.Warn("slow request", "status", 200, slog.Group("req", "method", "GET"))
+
+	var got map[string]any
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("expected a single json line, got %q: %v", out.String(), err)
+	}
+
+	want := map[string]any{
+		"level":           "warning",
+		"message":         "slow request",
+		"service":         "svc",
+		"scope":           "lib",
+		"http.status":     float64(200),
+		"http.req.method": "GET",
+	}
+
+	for key, value := range want {
+		if got[key] != value {
+			t.Fatalf("expected %s=%v, got %v (line %s)", key, value, got[key], out.String())
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package logger
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+)
+
+// MethodThresholds maps a full method ("/pkg.Service/Method") or a whole
+// service ("pkg.Service") to its slow-call threshold. It is parsed from
+// "key=duration" pairs separated by commas, e.g. "/wiki.v1.Wiki/Search=3s".
+type MethodThresholds map[string]time.Duration
+
+func (s *MethodThresholds) UnmarshalText(text []byte) error {
+	thresholds := make(MethodThresholds)
+
+	for _, pair := range strings.Split(string(text), ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+
+		method, value, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(method) == "" {
+			return fmt.Errorf("invalid slow-call threshold '%s', expected method=duration", pair)
+		}
+
+		threshold, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("invalid slow-call threshold for '%s': %w", method, err)
+		}
+
+		thresholds[strings.TrimSpace(method)] = threshold
+	}
+
+	*s = thresholds
+
+	return nil
+}
+
+// SlowCallConfig: unary calls taking at least Threshold (or the matching entry
+// of Methods) are logged at WARN. A zero threshold disables the check.
+// Streams are not checked, their duration is not a latency.
+type SlowCallConfig struct {
+	Threshold time.Duration
+	Methods   MethodThresholds
+}
+
+// SlowCall is passed to the OnSlowCall hooks. Side is "server" or "client".
+type SlowCall struct {
+	Side      string
+	Method    string
+	Target    string
+	Code      codes.Code
+	Latency   time.Duration
+	Threshold time.Duration
+}
+
+var (
+	slowCallRef atomic.Pointer[SlowCallConfig]
+
+	slowCallHooksMu sync.RWMutex
+	slowCallHooks   []slowCallHook
+	slowCallHookID  uint64
+)
+
+type slowCallHook struct {
+	id uint64
+	fn func(SlowCall)
+}
+
+func setSlowCallConfig(cfg SlowCallConfig) {
+	cfg.Methods = copyMethodThresholds(cfg.Methods)
+	slowCallRef.Store(&cfg)
+}
+
+// OnSlowCall registers fn to be called for every slow unary call, failed ones
+// included, e.g. by metrics.NewSlowCallMetrics. The returned function removes
+// the hook; calling it more than once is safe.
+func OnSlowCall(fn func(SlowCall)) (remove func()) {
+	if fn == nil {
+		return func() {}
+	}
+
+	slowCallHooksMu.Lock()
+	defer slowCallHooksMu.Unlock()
+
+	slowCallHookID++
+	id := slowCallHookID
+
+	slowCallHooks = append(slowCallHooks, slowCallHook{id: id, fn: fn})
+
+	return func() {
+		slowCallHooksMu.Lock()
+		defer slowCallHooksMu.Unlock()
+
+		// Copy, as checkSlowCall may still range over the old slice.
+		hooks := make([]slowCallHook, 0, len(slowCallHooks))
+		for _, hook := range slowCallHooks {
+			if hook.id != id {
+				hooks = append(hooks, hook)
+			}
+		}
+
+		slowCallHooks = hooks
+	}
+}
+
+func (c *SlowCallConfig) threshold(fullMethod string) time.Duration {
+	if threshold, ok := c.Methods[fullMethod]; ok {
+		return threshold
+	}
+
+	service, _, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if threshold, ok := c.Methods[service]; ok {
+		return threshold
+	}
+
+	return c.Threshold
+}
+
+// checkSlowCall runs the hooks for a slow call and, when it succeeded, logs a
+// WARN with logFields; failed calls are already logged as errors.
+func checkSlowCall(entry *logrus.Entry, logFields logrus.Fields, call SlowCall) {
+	cfg := slowCallRef.Load()
+	if cfg == nil {
+		return
+	}
+
+	call.Threshold = cfg.threshold(call.Method)
+	if call.Threshold <= 0 || call.Latency < call.Threshold {
+		return
+	}
+
+	if call.Code == codes.OK {
+		entry.WithFields(logFields).
+			WithField("slow_threshold_ms", call.Threshold.Milliseconds()).
+			Warnf("%s: slow gRPC request", call.Side)
+	}
+
+	slowCallHooksMu.RLock()
+	hooks := slowCallHooks
+	slowCallHooksMu.RUnlock()
+
+	for _, hook := range hooks {
+		hook.fn(call)
+	}
+}
+
+func copyMethodThresholds(thresholds MethodThresholds) MethodThresholds {
+	if len(thresholds) == 0 {
+		return nil
+	}
+
+	copied := make(MethodThresholds, len(thresholds))
+	for method, threshold := range thresholds {
+		copied[method] = threshold
+	}
+
+	return copied
+}
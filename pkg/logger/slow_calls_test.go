@@ -0,0 +1,52 @@
+package logger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+)
+
+func TestCheckSlowCall(t *testing.T) {
+	prevHooks := slowCallHooks
+
+	t.Cleanup(func() {
+		slowCallHooks = prevHooks
+		slowCallRef.Store(nil)
+	})
+
+	var methods MethodThresholds
+	if err := methods.UnmarshalText([]byte("/wiki.v1.Wiki/Search=3s, identity.v1.Identity=0s")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	setSlowCallConfig(SlowCallConfig{Threshold: time.Second, Methods: methods})
+
+	var reported []SlowCall
+	OnSlowCall(func(call SlowCall) { reported = append(reported, call) })
+
+	var out bytes.Buffer
+
+	l := logrus.New()
+	l.SetOutput(&out)
+	l.SetFormatter(&logrus.TextFormatter{DisableTimestamp: true})
+
+	entry := logrus.NewEntry(l)
+
+	checkSlowCall(entry, logrus.Fields{"rpc_method": "/wiki.v1.Wiki/Get"}, SlowCall{Side: "server", Method: "/wiki.v1.Wiki/Get", Latency: 1500 * time.Millisecond})
+	checkSlowCall(entry, logrus.Fields{}, SlowCall{Side: "server", Method: "/wiki.v1.Wiki/Search", Latency: 2 * time.Second})
+	checkSlowCall(entry, logrus.Fields{}, SlowCall{Side: "client", Method: "/identity.v1.Identity/Login", Latency: time.Minute})
+	checkSlowCall(entry, logrus.Fields{}, SlowCall{Side: "client", Method: "/wiki.v1.Wiki/Get", Code: codes.Unavailable, Latency: 5 * time.Second})
+
+	if len(reported) != 2 || reported[0].Threshold != time.Second || reported[1].Code != codes.Unavailable {
+		t.Fatalf("unexpected slow calls: %+v", reported)
+	}
+
+	got := out.String()
+	if strings.Count(got, "slow gRPC request") != 1 || !strings.Contains(got, "level=warning") || !strings.Contains(got, "slow_threshold_ms=1000") {
+		t.Fatalf("expected a single WARN for the successful slow call, got:\n%s", got)
+	}
+}
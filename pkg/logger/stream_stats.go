@@ -0,0 +1,61 @@
+package logger
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+)
+
+// streamStats counts stream messages and their encoded proto sizes.
+type streamStats struct {
+	msgsSent      atomic.Int64
+	msgsReceived  atomic.Int64
+	bytesSent     atomic.Int64
+	bytesReceived atomic.Int64
+}
+
+type streamStatsCtxKey struct{}
+
+func withStreamStats(ctx context.Context, stats *streamStats) context.Context {
+	return context.WithValue(ctx, streamStatsCtxKey{}, stats)
+}
+
+func streamStatsFromContext(ctx context.Context) *streamStats {
+	stats, _ := ctx.Value(streamStatsCtxKey{}).(*streamStats)
+	return stats
+}
+
+func (s *streamStats) sent(m any) int {
+	size := messageSize(m)
+
+	s.msgsSent.Add(1)
+	s.bytesSent.Add(int64(size))
+
+	return size
+}
+
+func (s *streamStats) received(m any) int {
+	size := messageSize(m)
+
+	s.msgsReceived.Add(1)
+	s.bytesReceived.Add(int64(size))
+
+	return size
+}
+
+func (s *streamStats) addFields(fields logrus.Fields) {
+	fields["msgs_sent"] = s.msgsSent.Load()
+	fields["msgs_received"] = s.msgsReceived.Load()
+	fields["bytes_sent"] = s.bytesSent.Load()
+	fields["bytes_received"] = s.bytesReceived.Load()
+}
+
+func messageSize(m any) int {
+	if msg, ok := m.(proto.Message); ok {
+		return proto.Size(msg)
+	}
+
+	return 0
+}
@@ -0,0 +1,160 @@
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) lines(t *testing.T) []map[string]any {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var lines []map[string]any
+
+	scanner := bufio.NewScanner(bytes.NewReader(b.buf.Bytes()))
+	for scanner.Scan() {
+		var line map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			t.Fatalf("unexpected log line %q: %v", scanner.Text(), err)
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+func echoStream(_ any, stream grpc.ServerStream) error {
+	for {
+		in := &wrapperspb.StringValue{}
+
+		err := stream.RecvMsg(in)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err := stream.SendMsg(in); err != nil {
+			return err
+		}
+	}
+}
+
+func TestStreamLoggingSummarizesCompletedStreams(t *testing.T) {
+	std := logrus.StandardLogger()
+	prevOut, prevFormatter, prevLevel := std.Out, std.Formatter, std.GetLevel()
+
+	t.Cleanup(func() {
+		std.SetOutput(prevOut)
+		std.SetFormatter(prevFormatter)
+		std.SetLevel(prevLevel)
+	})
+
+	var out syncBuffer
+
+	std.SetOutput(&out)
+	std.SetFormatter(jsonFormatter())
+	std.SetLevel(logrus.TraceLevel)
+
+	desc := &grpc.StreamDesc{StreamName: "Echo", Handler: echoStream, ServerStreams: true, ClientStreams: true}
+
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer(grpc.ChainStreamInterceptor(ServerStreamRequestIDInterceptor, ServerStreamLoggingInterceptor))
+	srv.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "test.Echo",
+		HandlerType: (*any)(nil),
+		Streams:     []grpc.StreamDesc{*desc},
+	}, struct{}{})
+
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithStreamInterceptor(ClientStreamInterceptor),
+	)
+	if err != nil {
+		t.Fatalf("unexpected dial error: %v", err)
+	}
+
+	defer conn.Close()
+
+	stream, err := conn.NewStream(context.Background(), desc, "/test.Echo/Echo")
+	if err != nil {
+		t.Fatalf("unexpected stream error: %v", err)
+	}
+
+	for _, value := range []string{"a", "bc"} {
+		if err := stream.SendMsg(wrapperspb.String(value)); err != nil {
+			t.Fatalf("unexpected send error: %v", err)
+		}
+
+		if err := stream.RecvMsg(&wrapperspb.StringValue{}); err != nil {
+			t.Fatalf("unexpected recv error: %v", err)
+		}
+	}
+
+	if err := stream.CloseSend(); err != nil {
+		t.Fatalf("unexpected close error: %v", err)
+	}
+
+	if err := stream.RecvMsg(&wrapperspb.StringValue{}); !errors.Is(err, io.EOF) {
+		t.Fatalf("expected io.EOF, got %v", err)
+	}
+
+	srv.GracefulStop()
+
+	summaries := map[string]map[string]any{}
+
+	for _, line := range out.lines(t) {
+		if line["level"] == "error" {
+			t.Fatalf("unexpected error line: %v", line)
+		}
+
+		if msg, _ := line["message"].(string); msg == "client: gRPC stream completed successfully" || msg == "server: gRPC stream completed successfully" {
+			summaries[msg] = line
+		}
+	}
+
+	if len(summaries) != 2 {
+		t.Fatalf("expected client and server summaries, got %v", summaries)
+	}
+
+	for msg, line := range summaries {
+		if line["msgs_sent"] != float64(2) || line["msgs_received"] != float64(2) || line["bytes_sent"] != float64(7) || line["grpc_code"] != float64(0) {
+			t.Fatalf("unexpected summary for %q: %v", msg, line)
+		}
+	}
+}
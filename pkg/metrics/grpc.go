@@ -2,7 +2,10 @@ package metrics
 
 import (
 	"context"
+	"errors"
+	"io"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -102,3 +105,152 @@ func NormalizeGRPCMethod(fullMethod string) string {
 
 	return trimmed
 }
+
+type GRPCClientMetrics struct {
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+func NewGRPCClientMetrics(reg *Registry) *GRPCClientMetrics {
+	if reg == nil {
+		return nil
+	}
+
+	requests := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "grpc_client_requests_total",
+			Help: "Total number of gRPC client requests.",
+		},
+		[]string{"target", "method", "code"},
+	)
+
+	duration := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "grpc_client_handling_seconds",
+			Help:    "Histogram of gRPC client request durations in seconds.",
+			Buckets: DefaultBuckets,
+		},
+		[]string{"target", "method", "code"},
+	)
+
+	reg.Registerer.MustRegister(requests, duration)
+
+	return &GRPCClientMetrics{
+		requests: requests,
+		duration: duration,
+	}
+}
+
+func (m *GRPCClientMetrics) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	if m == nil {
+		return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+	}
+
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+
+		m.observe(cc.Target(), method, err, time.Since(start))
+		return err
+	}
+}
+
+func (m *GRPCClientMetrics) StreamClientInterceptor() grpc.StreamClientInterceptor {
+	if m == nil {
+		return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			return streamer(ctx, desc, cc, method, opts...)
+		}
+	}
+
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		start := time.Now()
+
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			m.observe(cc.Target(), method, err, time.Since(start))
+			return nil, err
+		}
+
+		wrapped := &wrappedClientStreamMetrics{
+			ClientStream:  stream,
+			metrics:       m,
+			target:        cc.Target(),
+			method:        method,
+			startTime:     start,
+			serverStreams: desc.ServerStreams,
+		}
+
+		wrapped.stopCancel = context.AfterFunc(ctx, func() {
+			wrapped.finish(status.FromContextError(ctx.Err()).Err())
+		})
+
+		return wrapped, nil
+	}
+}
+
+func (m *GRPCClientMetrics) observe(target, fullMethod string, err error, took time.Duration) {
+	method := NormalizeGRPCMethod(fullMethod)
+	code := status.Code(err).String()
+
+	if m.requests != nil {
+		m.requests.WithLabelValues(target, method, code).Inc()
+	}
+
+	if m.duration != nil {
+		m.duration.WithLabelValues(target, method, code).Observe(took.Seconds())
+	}
+}
+
+type wrappedClientStreamMetrics struct {
+	grpc.ClientStream
+	metrics       *GRPCClientMetrics
+	target        string
+	method        string
+	startTime     time.Time
+	serverStreams bool
+	once          sync.Once
+	stopCancel    func() bool
+}
+
+func (w *wrappedClientStreamMetrics) SendMsg(m any) error {
+	err := w.ClientStream.SendMsg(m)
+
+	// On io.EOF the stream is broken and the status comes from the next RecvMsg.
+	if err != nil && !errors.Is(err, io.EOF) {
+		w.end(err)
+	}
+
+	return err
+}
+
+func (w *wrappedClientStreamMetrics) RecvMsg(m any) error {
+	err := w.ClientStream.RecvMsg(m)
+
+	switch {
+	case errors.Is(err, io.EOF):
+		w.end(nil)
+	case err != nil:
+		w.end(err)
+	case !w.serverStreams:
+		w.end(nil)
+	}
+
+	return err
+}
+
+// end is called from the stream methods, which only run after stopCancel is set.
+func (w *wrappedClientStreamMetrics) end(err error) {
+	w.stopCancel()
+	w.finish(err)
+}
+
+// finish observes the call once: on the first RecvMsg error (io.EOF counts as
+// success), after the only response of a client-streaming call, on a SendMsg
+// error or when the stream context is cancelled first.
+func (w *wrappedClientStreamMetrics) finish(err error) {
+	w.once.Do(func() {
+		w.metrics.observe(w.target, w.method, err, time.Since(w.startTime))
+	})
+}
@@ -0,0 +1,117 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/invenlore/core/pkg/serviceinfo"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var (
+	uploadDesc = grpc.StreamDesc{StreamName: "Upload", ClientStreams: true, Handler: uploadStream}
+	watchDesc  = grpc.StreamDesc{StreamName: "Watch", ServerStreams: true, Handler: watchStream}
+)
+
+func uploadStream(_ any, stream grpc.ServerStream) error {
+	for {
+		err := stream.RecvMsg(&wrapperspb.StringValue{})
+		if errors.Is(err, io.EOF) {
+			return stream.SendMsg(wrapperspb.String("done"))
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func watchStream(_ any, stream grpc.ServerStream) error {
+	<-stream.Context().Done()
+	return nil
+}
+
+func TestClientStreamMetricsObserveEveryCall(t *testing.T) {
+	m := NewGRPCClientMetrics(NewRegistryFromInfo(serviceinfo.Info{Name: "test"}))
+
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer()
+	srv.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "test.Streams",
+		HandlerType: (*any)(nil),
+		Streams:     []grpc.StreamDesc{uploadDesc, watchDesc},
+	}, struct{}{})
+
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithStreamInterceptor(m.StreamClientInterceptor()),
+	)
+	if err != nil {
+		t.Fatalf("unexpected dial error: %v", err)
+	}
+
+	defer conn.Close()
+
+	// Client streaming: CloseAndRecv returns the single response with a nil error.
+	upload, err := conn.NewStream(context.Background(), &uploadDesc, "/test.Streams/Upload")
+	if err != nil {
+		t.Fatalf("unexpected stream error: %v", err)
+	}
+
+	if err := upload.SendMsg(wrapperspb.String("chunk")); err != nil {
+		t.Fatalf("unexpected send error: %v", err)
+	}
+
+	if err := upload.CloseSend(); err != nil {
+		t.Fatalf("unexpected close error: %v", err)
+	}
+
+	if err := upload.RecvMsg(&wrapperspb.StringValue{}); err != nil {
+		t.Fatalf("unexpected recv error: %v", err)
+	}
+
+	// Cancelled before any RecvMsg: the caller abandons the stream.
+	ctx, cancel := context.WithCancel(context.Background())
+
+	if _, err := conn.NewStream(ctx, &watchDesc, "/test.Streams/Watch"); err != nil {
+		t.Fatalf("unexpected stream error: %v", err)
+	}
+
+	cancel()
+
+	target := conn.Target()
+	counters := []struct {
+		method string
+		code   string
+	}{
+		{method: "test.Streams/Upload", code: "OK"},
+		{method: "test.Streams/Watch", code: "Canceled"},
+	}
+
+	for _, c := range counters {
+		deadline := time.Now().Add(2 * time.Second)
+
+		for testutil.ToFloat64(m.requests.WithLabelValues(target, c.method, c.code)) != 1 {
+			if time.Now().After(deadline) {
+				t.Fatalf("expected one %s call with code %s", c.method, c.code)
+			}
+
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type HealthMetrics struct {
+	duration *prometheus.HistogramVec
+	status   *prometheus.GaugeVec
+}
+
+func NewHealthMetrics(reg *Registry) *HealthMetrics {
+	if reg == nil {
+		return nil
+	}
+
+	duration := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "invenlore_health_check_seconds",
+			Help:    "Health check duration in seconds.",
+			Buckets: DefaultBuckets,
+		},
+		[]string{"component", "status"},
+	)
+
+	status := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "invenlore_health_check_status",
+			Help: "Last health check status of service components (0/1).",
+		},
+		[]string{"component"},
+	)
+
+	reg.Registerer.MustRegister(duration, status)
+
+	return &HealthMetrics{
+		duration: duration,
+		status:   status,
+	}
+}
+
+func (m *HealthMetrics) ObserveCheck(component, status string, duration time.Duration) {
+	if m == nil {
+		return
+	}
+
+	if component == "" {
+		component = "unknown"
+	}
+	if status == "" {
+		status = "unknown"
+	}
+
+	m.duration.WithLabelValues(component, status).Observe(duration.Seconds())
+
+	if status == "up" {
+		m.status.WithLabelValues(component).Set(1)
+		return
+	}
+
+	m.status.WithLabelValues(component).Set(0)
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/invenlore/core/pkg/config"
+	"github.com/invenlore/core/pkg/serviceinfo"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -16,13 +17,20 @@ type Registry struct {
 	Service    string
 	Env        string
 	Version    string
+	InstanceID string
 }
 
 func NewRegistry(service string, env config.AppEnv, version string) *Registry {
+	return NewRegistryFromInfo(serviceinfo.Info{Name: service, Env: env.String(), Version: version})
+}
+
+// NewRegistryFromInfo labels every metric with service, env and version, and
+// adds instance_id and hostname to service_info only.
+func NewRegistryFromInfo(info serviceinfo.Info) *Registry {
 	labels := prometheus.Labels{
-		"service": service,
-		"env":     env.String(),
-		"version": version,
+		"service": info.Name,
+		"env":     info.Env,
+		"version": info.Version,
 	}
 
 	reg := prometheus.NewRegistry()
@@ -30,7 +38,11 @@ func NewRegistry(service string, env config.AppEnv, version string) *Registry {
 
 	serviceInfo := prometheus.NewGauge(prometheus.GaugeOpts{
 		Name: "service_info",
-		Help: "Static service metadata (service, env, version) with value 1.",
+		Help: "Static service metadata (service, env, version, instance_id, hostname) with value 1.",
+		ConstLabels: prometheus.Labels{
+			"instance_id": info.InstanceID,
+			"hostname":    info.Hostname,
+		},
 	})
 
 	reg.MustRegister(
@@ -46,9 +58,10 @@ func NewRegistry(service string, env config.AppEnv, version string) *Registry {
 		Registry:   reg,
 		Registerer: registerer,
 		Labels:     labels,
-		Service:    service,
-		Env:        env.String(),
-		Version:    version,
+		Service:    info.Name,
+		Env:        info.Env,
+		Version:    info.Version,
+		InstanceID: info.InstanceID,
 	}
 }
 
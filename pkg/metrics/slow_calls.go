@@ -0,0 +1,52 @@
+package metrics
+
+import (
+	"github.com/invenlore/core/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type SlowCallMetrics struct {
+	calls  *prometheus.CounterVec
+	remove func()
+}
+
+// NewSlowCallMetrics counts the unary calls reported by logger.OnSlowCall.
+// Streams are not counted. Close removes the hook, e.g. when the registry is
+// replaced.
+func NewSlowCallMetrics(reg *Registry) *SlowCallMetrics {
+	if reg == nil {
+		return nil
+	}
+
+	calls := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "grpc_slow_calls_total",
+			Help: "Total number of gRPC unary calls slower than the configured threshold.",
+		},
+		[]string{"side", "method", "code"},
+	)
+
+	reg.Registerer.MustRegister(calls)
+
+	m := &SlowCallMetrics{calls: calls}
+	m.remove = logger.OnSlowCall(m.observe)
+
+	return m
+}
+
+// Close stops counting slow calls.
+func (m *SlowCallMetrics) Close() {
+	if m == nil {
+		return
+	}
+
+	m.remove()
+}
+
+func (m *SlowCallMetrics) observe(call logger.SlowCall) {
+	if m == nil {
+		return
+	}
+
+	m.calls.WithLabelValues(call.Side, NormalizeGRPCMethod(call.Method), call.Code.String()).Inc()
+}
@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/invenlore/core/pkg/logger"
+	"github.com/invenlore/core/pkg/serviceinfo"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+)
+
+func TestSlowCallMetricsCountSlowCalls(t *testing.T) {
+	std := logrus.StandardLogger()
+	prevOut, prevFormatter, prevLevel := std.Out, std.Formatter, std.GetLevel()
+
+	logger.Init(logger.Config{Level: logger.LogLevelError, SlowCall: logger.SlowCallConfig{Threshold: time.Millisecond}})
+	std.SetOutput(io.Discard)
+
+	t.Cleanup(func() {
+		logger.Init(logger.Config{Level: logger.LogLevelInfo})
+		std.SetOutput(prevOut)
+		std.SetFormatter(prevFormatter)
+		std.SetLevel(prevLevel)
+	})
+
+	m := NewSlowCallMetrics(NewRegistryFromInfo(serviceinfo.Info{Name: "test"}))
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.v1.TestService/Get"}
+	slow := func(context.Context, any) (any, error) {
+		time.Sleep(5 * time.Millisecond)
+		return nil, nil
+	}
+	fast := func(context.Context, any) (any, error) {
+		return nil, nil
+	}
+
+	call := func(handler grpc.UnaryHandler) {
+		if _, err := logger.ServerLoggingInterceptor(context.Background(), nil, info, handler); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	call(fast)
+	call(slow)
+
+	counter := m.calls.WithLabelValues("server", NormalizeGRPCMethod(info.FullMethod), "OK")
+	if got := testutil.ToFloat64(counter); got != 1 {
+		t.Fatalf("expected 1 slow call, got %v", got)
+	}
+
+	// Close removes the hook and may be called twice.
+	m.Close()
+	m.Close()
+
+	call(slow)
+
+	if got := testutil.ToFloat64(counter); got != 1 {
+		t.Fatalf("expected no counting after Close, got %v", got)
+	}
+}
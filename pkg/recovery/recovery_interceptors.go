@@ -4,7 +4,7 @@ import (
 	"context"
 
 	"github.com/invenlore/core/pkg/errmodel"
-	"github.com/sirupsen/logrus"
+	"github.com/invenlore/core/pkg/logger"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 )
@@ -12,7 +12,7 @@ import (
 func RecoveryUnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
 	defer func() {
 		if r := recover(); r != nil {
-			logrus.Errorf("panic in unary handler %s: %v", info.FullMethod, r)
+			logger.FromContext(ctx).Errorf("panic in unary handler %s: %v", info.FullMethod, r)
 			err = errmodel.Error(ctx, codes.Internal, "internal error")
 		}
 	}()
@@ -23,7 +23,7 @@ func RecoveryUnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServ
 func RecoveryStreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
 	defer func() {
 		if r := recover(); r != nil {
-			logrus.Errorf("panic in stream handler %s: %v", info.FullMethod, r)
+			logger.FromContext(ss.Context()).Errorf("panic in stream handler %s: %v", info.FullMethod, r)
 			err = errmodel.Error(ss.Context(), codes.Internal, "internal error")
 		}
 	}()
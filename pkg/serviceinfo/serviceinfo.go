@@ -0,0 +1,129 @@
+package serviceinfo
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultVersionFile = "/app/version.txt"
+	DefaultEnv         = "dev"
+	Unknown            = "unknown"
+
+	maxVersionLength = 128
+)
+
+// Info identifies the running service instance in logs, metrics and traces.
+type Info struct {
+	Name       string
+	Version    string
+	Env        string
+	InstanceID string
+	Hostname   string
+}
+
+// Source holds the raw, possibly empty values Resolve starts from.
+type Source struct {
+	Name        string
+	Version     string
+	Env         string
+	VersionFile string
+	InstanceID  string
+}
+
+// SourceFromEnv reads SERVICE_NAME, SERVICE_VERSION, SERVICE_VERSION_FILE,
+// SERVICE_INSTANCE_ID and APP_ENV.
+func SourceFromEnv() Source {
+	return Source{
+		Name:        strings.TrimSpace(os.Getenv("SERVICE_NAME")),
+		Version:     strings.TrimSpace(os.Getenv("SERVICE_VERSION")),
+		Env:         strings.TrimSpace(os.Getenv("APP_ENV")),
+		VersionFile: strings.TrimSpace(os.Getenv("SERVICE_VERSION_FILE")),
+		InstanceID:  strings.TrimSpace(os.Getenv("SERVICE_INSTANCE_ID")),
+	}
+}
+
+func FromEnv() Info {
+	return Resolve(SourceFromEnv())
+}
+
+// Resolve fills in defaults and lowercases the env, so " Prod" and "prod"
+// produce the same labels and fields. The version is read from VersionFile (or
+// DefaultVersionFile) when not set, the instance ID falls back to the hostname
+// and then to a random ID that is stable for the lifetime of the process.
+func Resolve(src Source) Info {
+	info := Info{
+		Name:       strings.TrimSpace(src.Name),
+		Version:    strings.TrimSpace(src.Version),
+		Env:        strings.ToLower(strings.TrimSpace(src.Env)),
+		InstanceID: strings.TrimSpace(src.InstanceID),
+		Hostname:   hostname(),
+	}
+
+	if info.Version == "" {
+		path := src.VersionFile
+		if path == "" {
+			path = DefaultVersionFile
+		}
+
+		if version, err := ReadVersionFile(path); err == nil {
+			info.Version = version
+		}
+	}
+
+	if info.Name == "" {
+		info.Name = Unknown
+	}
+
+	if info.Version == "" {
+		info.Version = Unknown
+	}
+
+	if info.Env == "" {
+		info.Env = DefaultEnv
+	}
+
+	if info.InstanceID == "" {
+		info.InstanceID = info.Hostname
+	}
+
+	if info.InstanceID == "" {
+		info.InstanceID = processID()
+	}
+
+	return info
+}
+
+func ReadVersionFile(path string) (string, error) {
+	data, err := os.ReadFile(filepath.FromSlash(path))
+	if err != nil {
+		return "", err
+	}
+
+	version := strings.TrimSpace(string(data))
+	if version == "" {
+		return "", fmt.Errorf("service version file is empty")
+	}
+
+	if len(version) > maxVersionLength {
+		return "", fmt.Errorf("service version length exceeds limit")
+	}
+
+	return version, nil
+}
+
+func hostname() string {
+	name, err := os.Hostname()
+	if err != nil {
+		return ""
+	}
+
+	return name
+}
+
+var processID = sync.OnceValue(uuid.NewString)
@@ -0,0 +1,34 @@
+package serviceinfo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolve(t *testing.T) {
+	versionFile := filepath.Join(t.TempDir(), "version.txt")
+	if err := os.WriteFile(versionFile, []byte(" 1.2.3\n"), 0o600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	info := Resolve(Source{Name: "wiki", VersionFile: versionFile})
+
+	if info.Name != "wiki" || info.Version != "1.2.3" || info.Env != DefaultEnv {
+		t.Fatalf("unexpected info: %+v", info)
+	}
+
+	if info.InstanceID == "" || info.InstanceID != Resolve(Source{}).InstanceID {
+		t.Fatalf("expected a stable instance id, got %+v", info)
+	}
+
+	info = Resolve(Source{Version: "2.0.0", Env: " Prod\n", InstanceID: "pod-1", VersionFile: versionFile})
+
+	if info.Name != Unknown || info.Version != "2.0.0" || info.Env != "prod" || info.InstanceID != "pod-1" {
+		t.Fatalf("unexpected info: %+v", info)
+	}
+
+	if got := Resolve(Source{VersionFile: filepath.Join(t.TempDir(), "missing")}).Version; got != Unknown {
+		t.Fatalf("expected %q for a missing version file, got %q", Unknown, got)
+	}
+}
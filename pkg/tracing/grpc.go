@@ -0,0 +1,233 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/invenlore/core/pkg/logger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// metadataCarrier adapts gRPC metadata to propagation.TextMapCarrier.
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	if vals := metadata.MD(c).Get(key); len(vals) > 0 {
+		return vals[0]
+	}
+
+	return ""
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+func ServerTracingInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, span := startServerSpan(ctx, info.FullMethod)
+	defer span.End()
+
+	resp, err := handler(ctx, req)
+	finishSpan(span, err)
+
+	return resp, err
+}
+
+func ServerStreamTracingInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, span := startServerSpan(ss.Context(), info.FullMethod)
+	defer span.End()
+
+	err := handler(srv, &wrappedServerStreamTracing{ServerStream: ss, ctx: ctx})
+	finishSpan(span, err)
+
+	return err
+}
+
+// ClientTracingInterceptor starts a client span and injects traceparent into the outgoing metadata.
+func ClientTracingInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	ctx, span := startClientSpan(ctx, method, cc.Target())
+	defer span.End()
+
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	finishSpan(span, err)
+
+	return err
+}
+
+// ClientStreamTracingInterceptor ends the span when the stream fails or completes,
+// i.e. on the first RecvMsg error (io.EOF counts as success), for streams with
+// a single response after that response is received, on a SendMsg error or
+// when the stream context is cancelled first.
+func ClientStreamTracingInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	ctx, span := startClientSpan(ctx, method, cc.Target())
+
+	stream, err := streamer(ctx, desc, cc, method, opts...)
+	if err != nil {
+		finishSpan(span, err)
+		span.End()
+
+		return nil, err
+	}
+
+	wrapped := &wrappedClientStreamTracing{
+		ClientStream:  stream,
+		span:          span,
+		serverStreams: desc.ServerStreams,
+	}
+
+	wrapped.stopCancel = context.AfterFunc(ctx, func() {
+		wrapped.finish(status.FromContextError(ctx.Err()).Err())
+	})
+
+	return wrapped, nil
+}
+
+func startServerSpan(ctx context.Context, fullMethod string) (context.Context, trace.Span) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+
+	return tracer().Start(
+		ctx,
+		spanName(fullMethod),
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(rpcAttributes(ctx, fullMethod)...),
+	)
+}
+
+func startClientSpan(ctx context.Context, fullMethod, target string) (context.Context, trace.Span) {
+	attrs := append(rpcAttributes(ctx, fullMethod), attribute.String("rpc.target", target))
+
+	ctx, span := tracer().Start(
+		ctx,
+		spanName(fullMethod),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if !ok {
+		md = metadata.New(nil)
+	} else {
+		md = md.Copy()
+	}
+
+	otel.GetTextMapPropagator().Inject(ctx, metadataCarrier(md))
+
+	return metadata.NewOutgoingContext(ctx, md), span
+}
+
+func rpcAttributes(ctx context.Context, fullMethod string) []attribute.KeyValue {
+	service, method := splitMethod(fullMethod)
+
+	attrs := []attribute.KeyValue{
+		semconv.RPCSystemGRPC,
+		semconv.RPCService(service),
+		semconv.RPCMethod(method),
+	}
+
+	if requestID := logger.RequestIDFromContext(ctx); requestID != "" {
+		attrs = append(attrs, attribute.String("request_id", requestID))
+	}
+
+	return attrs
+}
+
+func finishSpan(span trace.Span, err error) {
+	st, _ := status.FromError(err)
+
+	span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(st.Code())))
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, st.Message())
+	}
+}
+
+func spanName(fullMethod string) string {
+	return strings.TrimPrefix(fullMethod, "/")
+}
+
+func splitMethod(fullMethod string) (string, string) {
+	name := spanName(fullMethod)
+
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		return name[:i], name[i+1:]
+	}
+
+	return "unknown", name
+}
+
+type wrappedServerStreamTracing struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (w *wrappedServerStreamTracing) Context() context.Context {
+	return w.ctx
+}
+
+type wrappedClientStreamTracing struct {
+	grpc.ClientStream
+	span          trace.Span
+	serverStreams bool
+	once          sync.Once
+	stopCancel    func() bool
+}
+
+func (w *wrappedClientStreamTracing) SendMsg(m any) error {
+	err := w.ClientStream.SendMsg(m)
+
+	// On io.EOF the stream is broken and the status comes from the next RecvMsg.
+	if err != nil && !errors.Is(err, io.EOF) {
+		w.end(err)
+	}
+
+	return err
+}
+
+func (w *wrappedClientStreamTracing) RecvMsg(m any) error {
+	err := w.ClientStream.RecvMsg(m)
+
+	switch {
+	case errors.Is(err, io.EOF):
+		w.end(nil)
+	case err != nil:
+		w.end(err)
+	case !w.serverStreams:
+		w.end(nil)
+	}
+
+	return err
+}
+
+// end is called from the stream methods, which only run after stopCancel is set.
+func (w *wrappedClientStreamTracing) end(err error) {
+	w.stopCancel()
+	w.finish(err)
+}
+
+func (w *wrappedClientStreamTracing) finish(err error) {
+	w.once.Do(func() {
+		finishSpan(w.span, err)
+		w.span.End()
+	})
+}
@@ -0,0 +1,199 @@
+package tracing
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/invenlore/core/pkg/config"
+	"go.opentelemetry.io/otel"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestInterceptorsPropagateTraceContext(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+
+	shutdown, err := Init(
+		&config.AppConfig{ServiceName: "test", Tracing: config.TracingConfig{Enabled: true, SampleRatio: 1}},
+		WithSpanExporter(exporter),
+	)
+	if err != nil {
+		t.Fatalf("unexpected init error: %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = shutdown(context.Background())
+		otel.SetTracerProvider(noop.NewTracerProvider())
+	})
+
+	var (
+		traceparent string
+		serverSpan  trace.SpanContext
+	)
+
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		ServerTracingInterceptor,
+		func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			if md, ok := metadata.FromIncomingContext(ctx); ok {
+				if vals := md.Get("traceparent"); len(vals) > 0 {
+					traceparent = vals[0]
+				}
+			}
+
+			serverSpan = trace.SpanContextFromContext(ctx)
+
+			return handler(ctx, req)
+		},
+	))
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithUnaryInterceptor(ClientTracingInterceptor),
+	)
+	if err != nil {
+		t.Fatalf("unexpected dial error: %v", err)
+	}
+
+	defer conn.Close()
+
+	if _, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{}); err != nil {
+		t.Fatalf("unexpected call error: %v", err)
+	}
+
+	if traceparent == "" {
+		t.Fatalf("expected traceparent metadata to be propagated")
+	}
+
+	if err := otel.GetTracerProvider().(*sdktrace.TracerProvider).ForceFlush(context.Background()); err != nil {
+		t.Fatalf("unexpected flush error: %v", err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("expected client and server spans, got %d", len(spans))
+	}
+
+	var client, server tracetest.SpanStub
+
+	for _, span := range spans {
+		switch span.SpanKind {
+		case trace.SpanKindClient:
+			client = span
+		case trace.SpanKindServer:
+			server = span
+		}
+	}
+
+	if server.SpanContext.TraceID() != client.SpanContext.TraceID() {
+		t.Fatalf("expected server span to join the client trace")
+	}
+
+	if server.Parent.SpanID() != client.SpanContext.SpanID() {
+		t.Fatalf("expected server span to be a child of the client span")
+	}
+
+	if serverSpan.SpanID() != server.SpanContext.SpanID() {
+		t.Fatalf("expected handler context to carry the server span")
+	}
+
+	if server.Name != "grpc.health.v1.Health/Check" {
+		t.Fatalf("unexpected span name %q", server.Name)
+	}
+}
+
+func TestClientStreamSpanEndsOnCancel(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+
+	shutdown, err := Init(
+		&config.AppConfig{ServiceName: "test", Tracing: config.TracingConfig{Enabled: true, SampleRatio: 1}},
+		WithSpanExporter(exporter),
+	)
+	if err != nil {
+		t.Fatalf("unexpected init error: %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = shutdown(context.Background())
+		otel.SetTracerProvider(noop.NewTracerProvider())
+	})
+
+	watchDesc := grpc.StreamDesc{
+		StreamName:    "Watch",
+		ServerStreams: true,
+		Handler: func(_ any, stream grpc.ServerStream) error {
+			<-stream.Context().Done()
+			return nil
+		},
+	}
+
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer()
+	srv.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "test.Streams",
+		HandlerType: (*any)(nil),
+		Streams:     []grpc.StreamDesc{watchDesc},
+	}, struct{}{})
+
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithStreamInterceptor(ClientStreamTracingInterceptor),
+	)
+	if err != nil {
+		t.Fatalf("unexpected dial error: %v", err)
+	}
+
+	defer conn.Close()
+
+	// The caller gives up without ever calling RecvMsg.
+	ctx, cancel := context.WithCancel(context.Background())
+
+	if _, err := conn.NewStream(ctx, &watchDesc, "/test.Streams/Watch"); err != nil {
+		t.Fatalf("unexpected stream error: %v", err)
+	}
+
+	cancel()
+
+	deadline := time.Now().Add(2 * time.Second)
+
+	for len(exporter.GetSpans()) == 0 {
+		_ = otel.GetTracerProvider().(*sdktrace.TracerProvider).ForceFlush(context.Background())
+
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the cancelled stream span to be ended")
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	span := exporter.GetSpans()[0]
+	if span.Name != "test.Streams/Watch" || span.Status.Code != otelcodes.Error {
+		t.Fatalf("unexpected span %q with status %v", span.Name, span.Status)
+	}
+}
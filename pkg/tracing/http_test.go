@@ -0,0 +1,65 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/invenlore/core/pkg/config"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestHTTPMiddlewareNamesSpansByRoute(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+
+	shutdown, err := Init(
+		&config.AppConfig{ServiceName: "test", Tracing: config.TracingConfig{Enabled: true, SampleRatio: 1}},
+		WithSpanExporter(exporter),
+	)
+	if err != nil {
+		t.Fatalf("unexpected init error: %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = shutdown(context.Background())
+		otel.SetTracerProvider(noop.NewTracerProvider())
+	})
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /items/{id}", func(http.ResponseWriter, *http.Request) {})
+	mux.HandleFunc("/static/", func(http.ResponseWriter, *http.Request) {})
+
+	handler := HTTPMiddleware(mux)
+
+	cases := []struct {
+		target   string
+		expected string
+	}{
+		{target: "/items/42", expected: "GET /items/{id}"},
+		{target: "/static/app.js", expected: "GET /static/"},
+		{target: "/unknown/42", expected: "GET"},
+	}
+
+	for _, tc := range cases {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tc.target, nil))
+	}
+
+	if err := otel.GetTracerProvider().(*sdktrace.TracerProvider).ForceFlush(context.Background()); err != nil {
+		t.Fatalf("unexpected flush error: %v", err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != len(cases) {
+		t.Fatalf("expected %d spans, got %d", len(cases), len(spans))
+	}
+
+	for i, tc := range cases {
+		if spans[i].Name != tc.expected {
+			t.Fatalf("%s: expected span name %q, got %q", tc.target, tc.expected, spans[i].Name)
+		}
+	}
+}
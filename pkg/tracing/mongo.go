@@ -0,0 +1,170 @@
+package tracing
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	defaultMongoMaxSpans   = 1024
+	defaultMongoMaxSpanAge = 5 * time.Minute
+)
+
+// MongoTracing creates a client span for every Mongo command. Use Monitor()
+// the same way as metrics.MongoMetrics.Monitor(), merged with db.MergeCommandMonitors
+// when both are needed.
+type MongoTracing struct {
+	mu       sync.Mutex
+	spans    map[int64]mongoSpanEntry
+	maxSpans int
+	maxAge   time.Duration
+}
+
+type mongoSpanEntry struct {
+	span      trace.Span
+	startedAt time.Time
+}
+
+func NewMongoTracing() *MongoTracing {
+	return &MongoTracing{
+		spans:    make(map[int64]mongoSpanEntry),
+		maxSpans: defaultMongoMaxSpans,
+		maxAge:   defaultMongoMaxSpanAge,
+	}
+}
+
+func (m *MongoTracing) Monitor() *event.CommandMonitor {
+	if m == nil {
+		return nil
+	}
+
+	return &event.CommandMonitor{
+		Started:   m.started,
+		Succeeded: m.succeeded,
+		Failed:    m.failed,
+	}
+}
+
+func (m *MongoTracing) started(ctx context.Context, ev *event.CommandStartedEvent) {
+	if ev == nil {
+		return
+	}
+
+	attrs := []attribute.KeyValue{
+		semconv.DBSystemNameMongoDB,
+		semconv.DBNamespace(ev.DatabaseName),
+		semconv.DBOperationName(ev.CommandName),
+	}
+
+	collection := extractCollection(ev.CommandName, ev.Command)
+	if collection != "" {
+		attrs = append(attrs, semconv.DBCollectionName(collection))
+	}
+
+	name := ev.CommandName
+	if collection != "" {
+		name += " " + collection
+	}
+
+	_, span := tracer().Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Started events without a matching Succeeded/Failed are not expected, but
+	// the map must never grow without bound.
+	if len(m.spans) >= m.maxSpans {
+		m.evictLocked(time.Now())
+	}
+
+	m.spans[ev.RequestID] = mongoSpanEntry{span: span, startedAt: time.Now()}
+}
+
+// evictLocked ends the spans older than maxAge and, if none are, the oldest
+// one, leaving the other in-flight commands untouched.
+func (m *MongoTracing) evictLocked(now time.Time) {
+	var (
+		oldestKey int64
+		oldest    time.Time
+	)
+
+	for key, entry := range m.spans {
+		if m.maxAge > 0 && now.Sub(entry.startedAt) > m.maxAge {
+			endStale(entry.span)
+			delete(m.spans, key)
+
+			continue
+		}
+
+		if oldest.IsZero() || entry.startedAt.Before(oldest) {
+			oldestKey, oldest = key, entry.startedAt
+		}
+	}
+
+	if len(m.spans) >= m.maxSpans && !oldest.IsZero() {
+		endStale(m.spans[oldestKey].span)
+		delete(m.spans, oldestKey)
+	}
+}
+
+func endStale(span trace.Span) {
+	span.SetStatus(otelcodes.Error, "command result not observed")
+	span.End()
+}
+
+func (m *MongoTracing) succeeded(_ context.Context, ev *event.CommandSucceededEvent) {
+	if ev == nil {
+		return
+	}
+
+	if span := m.take(ev.RequestID); span != nil {
+		span.End()
+	}
+}
+
+func (m *MongoTracing) failed(_ context.Context, ev *event.CommandFailedEvent) {
+	if ev == nil {
+		return
+	}
+
+	if span := m.take(ev.RequestID); span != nil {
+		span.SetStatus(otelcodes.Error, ev.Failure)
+		span.End()
+	}
+}
+
+func (m *MongoTracing) take(requestID int64) trace.Span {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, ok := m.spans[requestID]
+	if !ok {
+		return nil
+	}
+
+	delete(m.spans, requestID)
+
+	return entry.span
+}
+
+func extractCollection(commandName string, command bson.Raw) string {
+	if commandName == "" || command == nil {
+		return ""
+	}
+
+	if val := command.Lookup(commandName); val.Type != bson.TypeNull && val.Type != bson.TypeUndefined {
+		if name, ok := val.StringValueOK(); ok {
+			return name
+		}
+	}
+
+	return ""
+}
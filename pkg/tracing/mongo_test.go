@@ -0,0 +1,52 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/event"
+)
+
+func TestMongoTracingEvictsOnlyStaleSpans(t *testing.T) {
+	m := NewMongoTracing()
+	m.maxSpans = 3
+
+	start := func(requestID int64) {
+		m.started(context.Background(), &event.CommandStartedEvent{CommandName: "find", RequestID: requestID})
+	}
+
+	start(1)
+	start(2)
+	start(3)
+
+	// Request 1 is the oldest once 4 arrives; 2 and 3 are still in flight.
+	m.spans[1] = mongoSpanEntry{span: m.spans[1].span, startedAt: time.Now().Add(-time.Second)}
+
+	start(4)
+
+	if _, ok := m.spans[1]; ok {
+		t.Fatalf("expected the oldest span to be evicted")
+	}
+
+	for _, id := range []int64{2, 3, 4} {
+		if _, ok := m.spans[id]; !ok {
+			t.Fatalf("expected in-flight span %d to be kept", id)
+		}
+	}
+
+	// Expired spans go first, however many there are.
+	for _, id := range []int64{2, 3} {
+		m.spans[id] = mongoSpanEntry{span: m.spans[id].span, startedAt: time.Now().Add(-2 * m.maxAge)}
+	}
+
+	start(5)
+
+	if len(m.spans) != 2 {
+		t.Fatalf("expected expired spans to be evicted, got %d spans", len(m.spans))
+	}
+
+	if _, ok := m.spans[4]; !ok {
+		t.Fatalf("expected in-flight span 4 to be kept")
+	}
+}